@@ -115,6 +115,16 @@ func (am *AccountManager) ImportAccount(priKey, passphrase []byte) (*core.Addres
 	return addr, err
 }
 
+// Import import an account from the key file content
+func (am *AccountManager) Import(keyjson, passphrase []byte) (*core.Address, error) {
+	return am.addrManger.Import(keyjson, passphrase)
+}
+
+// Export export the key file content of the account
+func (am *AccountManager) Export(address *core.Address, passphrase []byte) ([]byte, error) {
+	return am.addrManger.Export(address, passphrase)
+}
+
 func (am *AccountManager) GetAllAddress() []*core.Address {
 	return am.addrManger.Accounts()
 }
@@ -0,0 +1,344 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"gamc.pro/gamcio/go-gamc/core"
+	"gamc.pro/gamcio/go-gamc/core/address"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/urfave/cli"
+	"io/ioutil"
+	"strings"
+)
+
+var (
+	ErrMissingAddress = errors.New("address must be given as argument")
+	ErrMissingKeyFile = errors.New("keyfile must be given as argument")
+)
+
+var (
+	// PrivateKeyFlag treat the import file as a hex encoded private key
+	PrivateKeyFlag = cli.BoolFlag{
+		Name:  "privatekey",
+		Usage: "the file to import holds a hex encoded private key instead of a key json",
+	}
+
+	// MnemonicFlag export the mnemonic instead of the key json
+	MnemonicFlag = cli.BoolFlag{
+		Name:  "mnemonic",
+		Usage: "print the mnemonic of the account instead of its key json",
+	}
+
+	accountCommand = cli.Command{
+		Name:     "account",
+		Usage:    "Manage accounts",
+		Category: "ACCOUNT COMMANDS",
+		Description: `
+Manage accounts, list all existing accounts, create a new account, import or
+export an account, update the passphrase of an account and remove an account.
+
+The accounts are stored in the keydir configured in the chain section of the
+config file. Passphrases are prompted without echo, use --password to read them
+from a file instead.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "new",
+				Usage:  "Create a new account",
+				Action: accountCreate,
+				Flags:  []cli.Flag{PasswordFlag},
+				Description: `
+    gamc account new
+
+Creates a new account and prints the address and its mnemonic. The
+account is saved in encrypted format, you are prompted for a passphrase.`,
+			},
+			{
+				Name:   "list",
+				Usage:  "Print summary of existing accounts",
+				Action: accountList,
+			},
+			{
+				Name:      "import",
+				Usage:     "Import an account from a key file or a private key file",
+				Action:    accountImport,
+				ArgsUsage: "<keyFile>",
+				Flags:     []cli.Flag{PasswordFlag, PrivateKeyFlag},
+				Description: `
+    gamc account import <keyfile>
+
+Imports an account from a key json file, you are prompted for the passphrase
+of the key file. With --privatekey the file holds a hex encoded private key
+and you are prompted for a new passphrase.`,
+			},
+			{
+				Name:   "import-mnemonic",
+				Usage:  "Recover an account from its mnemonic",
+				Action: accountImportMnemonic,
+				Flags:  []cli.Flag{PasswordFlag},
+				Description: `
+    gamc account import-mnemonic
+
+Reads the mnemonic from stdin, recovers the private key and saves it in
+encrypted format, you are prompted for a new passphrase.`,
+			},
+			{
+				Name:      "export",
+				Usage:     "Print the key json or the mnemonic of an account",
+				Action:    accountExport,
+				ArgsUsage: "<address>",
+				Flags:     []cli.Flag{PasswordFlag, MnemonicFlag},
+			},
+			{
+				Name:      "update",
+				Usage:     "Update the passphrase of an account",
+				Action:    accountUpdate,
+				ArgsUsage: "<address>",
+				Flags:     []cli.Flag{PasswordFlag},
+				Description: `
+    gamc account update <address>
+
+You are prompted for the current passphrase and the new one. With
+--password, the first line is the current passphrase and the second
+line the new one.`,
+			},
+			{
+				Name:      "remove",
+				Usage:     "Remove an account from the keydir",
+				Action:    accountRemove,
+				ArgsUsage: "<address>",
+				Flags:     []cli.Flag{PasswordFlag},
+			},
+		},
+	}
+)
+
+func makeAddressManager(ctx *cli.Context) (*address.AddressManager, error) {
+	conf, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return address.NewAddressManager(conf)
+}
+
+func addressArg(ctx *cli.Context) (*core.Address, error) {
+	if len(ctx.Args()) == 0 {
+		return nil, ErrMissingAddress
+	}
+	return core.AddressParse(ctx.Args().First())
+}
+
+func accountCreate(ctx *cli.Context) error {
+	am, err := makeAddressManager(ctx)
+	if err != nil {
+		return err
+	}
+	passwords, err := passwordList(ctx)
+	if err != nil {
+		return err
+	}
+	passphrase, err := getPassPhrase("Your new account is locked with a passphrase. Please give a passphrase. Do not forget this passphrase.", true, 0, passwords)
+	if err != nil {
+		return err
+	}
+
+	addr, err := am.NewAddress(passphrase)
+	if err != nil {
+		return err
+	}
+	memo, err := am.GetMnemonic(addr, passphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Address: %s\n", addr)
+	fmt.Printf("Mnemonic: %s\n", memo)
+	return nil
+}
+
+func accountList(ctx *cli.Context) error {
+	am, err := makeAddressManager(ctx)
+	if err != nil {
+		return err
+	}
+	for i, addr := range am.Accounts() {
+		fmt.Printf("Account #%d: %s\n", i, addr)
+	}
+	return nil
+}
+
+func accountImport(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		return ErrMissingKeyFile
+	}
+	data, err := ioutil.ReadFile(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	am, err := makeAddressManager(ctx)
+	if err != nil {
+		return err
+	}
+	passwords, err := passwordList(ctx)
+	if err != nil {
+		return err
+	}
+
+	var addr *core.Address
+	if ctx.Bool(PrivateKeyFlag.Name) {
+		priKey, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil {
+			return address.ErrInvalidPrivateKey
+		}
+		passphrase, err := getPassPhrase("Your new account is locked with a passphrase. Please give a passphrase. Do not forget this passphrase.", true, 0, passwords)
+		if err != nil {
+			return err
+		}
+		addr, err = am.ImportByPrivateKey(priKey, passphrase)
+		if err != nil {
+			return err
+		}
+	} else {
+		passphrase, err := getPassPhrase("Please give the passphrase of the key file.", false, 0, passwords)
+		if err != nil {
+			return err
+		}
+		addr, err = am.Import(data, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Import address: %s\n", addr)
+	return nil
+}
+
+func accountImportMnemonic(ctx *cli.Context) error {
+	am, err := makeAddressManager(ctx)
+	if err != nil {
+		return err
+	}
+	passwords, err := passwordList(ctx)
+	if err != nil {
+		return err
+	}
+	memo, err := readSecret("Mnemonic: ")
+	if err != nil {
+		return err
+	}
+	priKey, err := am.GetPrivateKeyBytMnemonic(strings.Join(strings.Fields(string(memo)), " "))
+	if err != nil {
+		return err
+	}
+	passphrase, err := getPassPhrase("Your new account is locked with a passphrase. Please give a passphrase. Do not forget this passphrase.", true, 0, passwords)
+	if err != nil {
+		return err
+	}
+	addr, err := am.ImportByPrivateKey(priKey, passphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Import address: %s\n", addr)
+	return nil
+}
+
+func accountExport(ctx *cli.Context) error {
+	addr, err := addressArg(ctx)
+	if err != nil {
+		return err
+	}
+	am, err := makeAddressManager(ctx)
+	if err != nil {
+		return err
+	}
+	passwords, err := passwordList(ctx)
+	if err != nil {
+		return err
+	}
+	passphrase, err := getPassPhrase("", false, 0, passwords)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(MnemonicFlag.Name) {
+		memo, err := am.GetMnemonic(addr, passphrase)
+		if err != nil {
+			return err
+		}
+		fmt.Println(memo)
+		return nil
+	}
+	data, err := am.Export(addr, passphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+func accountUpdate(ctx *cli.Context) error {
+	addr, err := addressArg(ctx)
+	if err != nil {
+		return err
+	}
+	am, err := makeAddressManager(ctx)
+	if err != nil {
+		return err
+	}
+	passwords, err := passwordList(ctx)
+	if err != nil {
+		return err
+	}
+	oldPassphrase, err := getPassPhrase("Please give the current passphrase.", false, 0, passwords)
+	if err != nil {
+		return err
+	}
+	newPassphrase, err := getPassPhrase("Please give a new passphrase. Do not forget this passphrase.", true, 1, passwords)
+	if err != nil {
+		return err
+	}
+	if err := am.UpdatePassphrase(addr, oldPassphrase, newPassphrase); err != nil {
+		return err
+	}
+	fmt.Printf("Updated address: %s\n", addr)
+	return nil
+}
+
+func accountRemove(ctx *cli.Context) error {
+	addr, err := addressArg(ctx)
+	if err != nil {
+		return err
+	}
+	am, err := makeAddressManager(ctx)
+	if err != nil {
+		return err
+	}
+	passwords, err := passwordList(ctx)
+	if err != nil {
+		return err
+	}
+	passphrase, err := getPassPhrase("", false, 0, passwords)
+	if err != nil {
+		return err
+	}
+	if err := am.RemoveAddress(addr, passphrase); err != nil {
+		return err
+	}
+	fmt.Printf("Removed address: %s\n", addr)
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"gamc.pro/gamcio/go-gamc/core"
+	"gamc.pro/gamcio/go-gamc/gamc"
+	"errors"
+	"fmt"
+	"github.com/urfave/cli"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	progressInterval = 5 * time.Second
+)
+
+var (
+	ErrMissingArchiveFile = errors.New("archive file must be given as argument")
+)
+
+var (
+	// GzipFlag compress the archive
+	GzipFlag = cli.BoolFlag{
+		Name:  "gzip",
+		Usage: "gzip compress the archive, implied by a .gz file name",
+	}
+
+	exportCommand = cli.Command{
+		Name:      "export",
+		Usage:     "Export the canonical blocks to an archive file",
+		Action:    exportChain,
+		ArgsUsage: "<file> [from] [to]",
+		Category:  "BLOCKCHAIN COMMANDS",
+		Flags:     []cli.Flag{GzipFlag},
+		Description: `
+Exports the canonical blocks in [from, to] to the archive file. By default
+all the blocks after the genesis block up to the tail are exported.`,
+	}
+
+	importCommand = cli.Command{
+		Name:      "import",
+		Usage:     "Import the blocks of an archive file",
+		Action:    importChain,
+		ArgsUsage: "<file>",
+		Category:  "BLOCKCHAIN COMMANDS",
+		Description: `
+Imports the blocks of an archive file created by the export command. The
+blocks are verified and executed as the blocks received from network.`,
+	}
+)
+
+// makeChain setup the block chain without network, the caller should stop it.
+func makeChain(ctx *cli.Context) (*gamc.Gamc, error) {
+	conf, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	node, err := gamc.New(conf)
+	if err != nil {
+		return nil, err
+	}
+	if err := node.SetupChain(); err != nil {
+		node.Stop()
+		return nil, err
+	}
+	return node, nil
+}
+
+// progressReporter prints the progress of a long running command periodically.
+type progressReporter struct {
+	action string
+	start  time.Time
+	last   time.Time
+	count  int
+}
+
+func newProgressReporter(action string) *progressReporter {
+	now := time.Now()
+	return &progressReporter{action: action, start: now, last: now}
+}
+
+func (p *progressReporter) update(block *core.Block) {
+	p.count++
+	if time.Since(p.last) < progressInterval {
+		return
+	}
+	p.last = time.Now()
+	fmt.Printf("%s %d blocks, height %d, elapsed %s\n", p.action, p.count, block.Height(), time.Since(p.start).Round(time.Second))
+}
+
+func (p *progressReporter) done() {
+	fmt.Printf("%s %d blocks in %s\n", p.action, p.count, time.Since(p.start).Round(time.Millisecond))
+}
+
+func exportChain(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		return ErrMissingArchiveFile
+	}
+	path := ctx.Args().Get(0)
+
+	node, err := makeChain(ctx)
+	if err != nil {
+		return err
+	}
+	defer node.Stop()
+
+	from, to := uint64(1), node.BlockChain().TailBlock().Height()
+	if arg := ctx.Args().Get(1); arg != "" {
+		if from, err = strconv.ParseUint(arg, 10, 64); err != nil {
+			return err
+		}
+	}
+	if arg := ctx.Args().Get(2); arg != "" {
+		if to, err = strconv.ParseUint(arg, 10, 64); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	compress := ctx.Bool(GzipFlag.Name) || strings.HasSuffix(path, ".gz")
+	progress := newProgressReporter("Exported")
+	if err := node.BlockChain().ExportArchive(file, from, to, compress, progress.update); err != nil {
+		return err
+	}
+	progress.done()
+	return file.Sync()
+}
+
+func importChain(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		return ErrMissingArchiveFile
+	}
+	file, err := os.Open(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	node, err := makeChain(ctx)
+	if err != nil {
+		return err
+	}
+	defer node.Stop()
+
+	progress := newProgressReporter("Imported")
+	err = node.BlockChain().ImportArchive(file, progress.update)
+	progress.done()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Tail block: %d %s\n", node.BlockChain().TailBlock().Height(), node.BlockChain().TailBlock().Hash())
+	return nil
+}
@@ -0,0 +1,348 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"gamc.pro/gamcio/go-gamc/core"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"errors"
+	"fmt"
+	"github.com/urfave/cli"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrMissingHeight        = errors.New("height must be given as argument")
+	ErrInconsistentDatabase = errors.New("database is inconsistent, run with --repair to fix it")
+	ErrBackupExists         = errors.New("backup of the database already exists")
+	ErrMigrationAborted     = errors.New("migration aborted")
+	ErrRevertedTxs          = errors.New("the discarded blocks have transactions, pass --drop-txs to drop them")
+)
+
+var (
+	// ForceFlag force the operation
+	ForceFlag = cli.BoolFlag{
+		Name:  "force",
+		Usage: "allow to rewind below the fixed block",
+	}
+
+	// DropTxsFlag allow to drop the transactions of the discarded blocks
+	DropTxsFlag = cli.BoolFlag{
+		Name:  "drop-txs",
+		Usage: "allow to drop the transactions of the discarded blocks",
+	}
+
+	// RepairFlag repair the problems found
+	RepairFlag = cli.BoolFlag{
+		Name:  "repair",
+		Usage: "reset the tail to the last fully consistent block",
+	}
+
+	// SnapshotFlag the height of the snapshot to start from
+	SnapshotFlag = cli.Uint64Flag{
+		Name:  "snapshot",
+		Usage: "copy the state at this height instead of replaying from genesis",
+	}
+
+	// TargetFlag the directory of the rebuilt database
+	TargetFlag = cli.StringFlag{
+		Name:  "target",
+		Usage: "directory of the rebuilt database, defaults to <db_dir>.reindex",
+	}
+
+	// ReplaceFlag replace the database by the rebuilt one
+	ReplaceFlag = cli.BoolFlag{
+		Name:  "replace",
+		Usage: "replace the database by the rebuilt one, keeping it as <db_dir>.bak",
+	}
+
+	// YesFlag answer yes to the confirmation prompts
+	YesFlag = cli.BoolFlag{
+		Name:  "yes, y",
+		Usage: "don't ask for confirmation",
+	}
+
+	dbCommand = cli.Command{
+		Name:     "db",
+		Usage:    "Low level database operations",
+		Category: "DATABASE COMMANDS",
+		Subcommands: []cli.Command{
+			{
+				Name:   "verify",
+				Usage:  "Verify the integrity of the chain data",
+				Action: dbVerify,
+				Flags:  []cli.Flag{RepairFlag},
+				Description: `
+    gamc db verify [--repair]
+
+Walks from the tail block back to genesis, checking the hash, parent link
+and height of each block, that its state and txs tries are fully present,
+and that the tail and fixed pointers are consistent. The node must not be
+running. With --repair, the tail is reset to the last fully consistent block.`,
+			},
+			{
+				Name:      "sethead",
+				Usage:     "Rewind the canonical chain to the given height",
+				Action:    dbSetHead,
+				ArgsUsage: "<height>",
+				Flags:     []cli.Flag{ForceFlag, DropTxsFlag},
+				Description: `
+    gamc db sethead <height> [--force] [--drop-txs]
+
+Moves the tail back to the block at height and deletes the discarded blocks
+with their indices. The node must not be running. The transactions of the
+discarded blocks would be lost, as the tx pool is not saved offline, so the
+rewind is refused if there are any unless --drop-txs is given. Use the
+SetHead admin API of a running node instead to return them to its pool.
+Rewinding below the fixed block is refused unless --force is given.`,
+			},
+			{
+				Name:   "reindex",
+				Usage:  "Rebuild the state and indices by replaying the stored blocks",
+				Action: dbReindex,
+				Flags:  []cli.Flag{SnapshotFlag, TargetFlag, ReplaceFlag},
+				Description: `
+    gamc db reindex [--snapshot height] [--target dir] [--replace]
+
+Re-executes the canonical blocks from genesis into a fresh database,
+verifying the stateRoot and txsRoot of every block and rebuilding the
+height and tx indices. With --snapshot, the state at that height is copied
+instead of replayed, the states of the blocks below it are not kept. The
+progress is checkpointed, running the command again with the same target
+resumes an interrupted reindex. The node must not be running.`,
+			},
+			{
+				Name:   "migrate",
+				Usage:  "Upgrade the database schema to the current version",
+				Action: dbMigrate,
+				Flags:  []cli.Flag{YesFlag},
+				Description: `
+    gamc db migrate [--yes]
+
+Upgrades the database in place through the migrations between its schema
+version and the one of this release. The upgrade can't be undone, back up
+the database first. An interrupted upgrade resumes from the last completed
+migration. The node must not be running.`,
+			},
+		},
+	}
+)
+
+// makeStorage open the storage of the chain, the caller should close it.
+func makeStorage(ctx *cli.Context) (cdb.Storage, error) {
+	conf, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db, err := cdb.NewDB(conf)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.InitSchema(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func dbVerify(ctx *cli.Context) error {
+	db, err := makeStorage(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	progress := newProgressReporter("Verified")
+	report, err := core.VerifyChainIntegrity(db, progress.update)
+	if err != nil {
+		return err
+	}
+	progress.done()
+
+	fmt.Printf("Tail:       %s\n", report.Tail)
+	fmt.Printf("Fixed:      %s\n", report.Fixed)
+	fmt.Printf("Checked:    %d blocks, %d trie nodes\n", report.Checked, report.TrieNodes)
+	if report.LastGood != nil {
+		fmt.Printf("Last good:  %d %s\n", report.LastGood.Height(), report.LastGood.Hash())
+	} else {
+		fmt.Println("Last good:  none")
+	}
+	if len(report.StaleIndices) > 0 {
+		fmt.Printf("Stale height indices: %d\n", len(report.StaleIndices))
+	}
+	for _, problem := range report.Problems {
+		fmt.Printf("Problem:    %s\n", problem)
+	}
+
+	if report.Clean() {
+		fmt.Println("Database is consistent.")
+		return nil
+	}
+	if !ctx.Bool(RepairFlag.Name) {
+		return ErrInconsistentDatabase
+	}
+	if err := core.RepairChainIntegrity(db, report); err != nil {
+		return err
+	}
+	fmt.Printf("Repaired, tail reset to %d %s\n", report.LastGood.Height(), report.LastGood.Hash())
+	return nil
+}
+
+func dbSetHead(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		return ErrMissingHeight
+	}
+	height, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
+	if err != nil {
+		return err
+	}
+
+	node, err := makeChain(ctx)
+	if err != nil {
+		return err
+	}
+	defer node.Stop()
+
+	chain := node.BlockChain()
+	if !ctx.Bool(DropTxsFlag.Name) {
+		// the tx pool is not saved offline, refuse to lose the txs silently.
+		for block := chain.TailBlock(); block != nil && block.Height() > height; block = chain.GetBlock(block.ParentHash()) {
+			if len(block.Transactions()) > 0 {
+				return ErrRevertedTxs
+			}
+		}
+	}
+	reverted, err := chain.SetHead(height, ctx.Bool(ForceFlag.Name))
+	if err != nil {
+		return err
+	}
+	txs := 0
+	for _, block := range reverted {
+		txs += len(block.Transactions())
+	}
+	fmt.Printf("Discarded %d blocks, %d transactions of them are dropped\n", len(reverted), txs)
+	fmt.Printf("Tail:  %d %s\n", chain.TailBlock().Height(), chain.TailBlock().Hash())
+	fmt.Printf("Fixed: %d %s\n", chain.FixedBlock().Height(), chain.FixedBlock().Hash())
+	return nil
+}
+
+func dbReindex(ctx *cli.Context) error {
+	conf, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	dbcfg := cdb.GetDbConfig(conf)
+	source, err := cdb.NewDBWithConfig(dbcfg)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	sourceDir := dbcfg.DbDir
+	backupDir := sourceDir + ".bak"
+	replace := ctx.Bool(ReplaceFlag.Name)
+	if replace {
+		if _, err := os.Stat(backupDir); err == nil {
+			return ErrBackupExists
+		}
+	}
+
+	targetcfg := *dbcfg
+	targetcfg.DbDir = ctx.String(TargetFlag.Name)
+	if targetcfg.DbDir == "" {
+		targetcfg.DbDir = sourceDir + ".reindex"
+	}
+	target, err := cdb.NewDBWithConfig(&targetcfg)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+
+	progress := newProgressReporter("Reindexed")
+	tail, err := core.ReindexChain(conf, source, target, ctx.Uint64(SnapshotFlag.Name), progress.update)
+	if err != nil {
+		return err
+	}
+	progress.done()
+	fmt.Printf("Tail: %d %s\n", tail.Height(), tail.Hash())
+
+	if !replace {
+		fmt.Printf("Rebuilt database in %s\n", targetcfg.DbDir)
+		return nil
+	}
+	if err := source.Close(); err != nil {
+		return err
+	}
+	if err := target.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(sourceDir, backupDir); err != nil {
+		return err
+	}
+	if err := os.Rename(targetcfg.DbDir, sourceDir); err != nil {
+		return err
+	}
+	fmt.Printf("Replaced %s, the old database is kept in %s\n", sourceDir, backupDir)
+	return nil
+}
+
+func dbMigrate(ctx *cli.Context) error {
+	conf, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	dbcfg := cdb.GetDbConfig(conf)
+	db, err := cdb.NewDBWithConfig(dbcfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	version, err := core.ReadSchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if version == core.SchemaVersion {
+		fmt.Printf("Database schema is up to date, version %d\n", version)
+		return nil
+	}
+	if version > core.SchemaVersion {
+		return core.ErrNewerSchema
+	}
+
+	if !ctx.Bool("yes") {
+		fmt.Printf("Database %s will be upgraded in place from schema version %d to %d.\n", dbcfg.DbDir, version, core.SchemaVersion)
+		fmt.Print("The upgrade can't be undone, back up the database first. Continue? [y/N] ")
+		answer, err := stdinReader.ReadString('\n')
+		if err != nil && len(answer) == 0 {
+			return err
+		}
+		if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return ErrMigrationAborted
+		}
+	}
+
+	if _, err := core.MigrateSchema(db); err != nil {
+		return err
+	}
+	fmt.Printf("Database schema upgraded from version %d to %d\n", version, core.SchemaVersion)
+	return nil
+}
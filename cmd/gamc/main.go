@@ -0,0 +1,113 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"gamc.pro/gamcio/go-gamc/gamc"
+	"gamc.pro/gamcio/go-gamc/network"
+	"gamc.pro/gamcio/go-gamc/util/config"
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var (
+	// ConfigFlag config file path
+	ConfigFlag = cli.StringFlag{
+		Name:  "config, c",
+		Usage: "load configuration from `FILE`",
+		Value: config.DefaultConfigPath,
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "gamc"
+	app.Usage = "the go-gamc command line interface"
+	app.Version = network.ClientVersion
+	app.Flags = []cli.Flag{ConfigFlag}
+	app.Action = runGamc
+	app.Commands = []cli.Command{
+		accountCommand,
+		txCommand,
+		exportCommand,
+		importCommand,
+		dbCommand,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// loadConfig load the config file given by the config flag and init the loggers.
+func loadConfig(ctx *cli.Context) (*config.Config, error) {
+	conf, err := config.InitConfig(ctx.GlobalString("config"))
+	if err != nil {
+		return nil, err
+	}
+
+	logcfg := logging.GetLogConfig(conf)
+	logging.Init(logcfg.LogFile, logcfg.LogLevel, logcfg.LogAge)
+	return conf, nil
+}
+
+func runGamc(ctx *cli.Context) error {
+	conf, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	node, err := gamc.New(conf)
+	if err != nil {
+		return err
+	}
+	if err := node.Setup(); err != nil {
+		node.Stop()
+		return err
+	}
+	if err := node.Start(); err != nil {
+		node.Stop()
+		return err
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	sig := <-sigCh
+	logging.CLog().WithFields(logrus.Fields{
+		"signal": sig,
+	}).Info("Received signal, shutting down...")
+
+	// a second signal aborts the ordered shutdown.
+	go func() {
+		sig := <-sigCh
+		logging.CLog().WithFields(logrus.Fields{
+			"signal": sig,
+		}).Error("Received signal again, exit immediately.")
+		os.Exit(1)
+	}()
+
+	node.Stop()
+	return nil
+}
@@ -0,0 +1,387 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"gamc.pro/gamcio/go-gamc/conf"
+	"gamc.pro/gamcio/go-gamc/core"
+	corepb "gamc.pro/gamcio/go-gamc/core/pb"
+	"gamc.pro/gamcio/go-gamc/crypto"
+	"gamc.pro/gamcio/go-gamc/crypto/cipher"
+	"gamc.pro/gamcio/go-gamc/crypto/keystore"
+	"gamc.pro/gamcio/go-gamc/rpc"
+	rpcpb "gamc.pro/gamcio/go-gamc/rpc/pb"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gogo/protobuf/proto"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingTxFile    = errors.New("transaction file must be given as argument")
+	ErrMissingTxFrom    = errors.New("--from and --to must be given")
+	ErrInvalidTxAmount  = errors.New("invalid value or fee")
+	ErrKeyNotMatchFrom  = errors.New("the key does not belong to the sender of the transaction")
+	ErrNoRpcEndpoint    = errors.New("no rpc endpoint, use --rpc to specify one")
+	ErrInvalidTxPayload = errors.New("payload must be hex encoded")
+)
+
+var (
+	// OutputFlag output file
+	OutputFlag = cli.StringFlag{
+		Name:  "output, o",
+		Usage: "write the transaction to `FILE` instead of printing it in hex",
+	}
+
+	// HexFlag write hex instead of raw bytes
+	HexFlag = cli.BoolFlag{
+		Name:  "hex",
+		Usage: "write the output file hex encoded instead of raw bytes",
+	}
+
+	txCommand = cli.Command{
+		Name:     "tx",
+		Usage:    "Build, sign, decode and send transactions",
+		Category: "TRANSACTION COMMANDS",
+		Description: `
+Build and sign transactions offline and broadcast them through a node.
+
+The transaction files hold the raw bytes of a corepb.Transaction, either
+binary or hex encoded. Use "-" to read a transaction from stdin.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "build",
+				Usage:  "Build an unsigned transaction",
+				Action: txBuild,
+				Flags: []cli.Flag{
+					cli.StringFlag{Name: "from", Usage: "sender `ADDRESS`"},
+					cli.StringFlag{Name: "to", Usage: "receiver `ADDRESS`"},
+					cli.StringFlag{Name: "value", Value: "0", Usage: "transfer amount"},
+					cli.StringFlag{Name: "fee", Value: "0", Usage: "transaction fee"},
+					cli.Uint64Flag{Name: "nonce", Usage: "transaction nonce"},
+					cli.UintFlag{Name: "chainid", Usage: "chain id, defaults to the chain_id of the config file"},
+					cli.UintFlag{Name: "priority", Usage: "transaction priority"},
+					cli.StringFlag{Name: "type", Value: core.TxPayloadBinaryType, Usage: "payload type"},
+					cli.StringFlag{Name: "payload", Usage: "hex encoded payload"},
+					OutputFlag,
+					HexFlag,
+				},
+			},
+			{
+				Name:      "sign",
+				Usage:     "Sign a transaction with a key file",
+				Action:    txSign,
+				ArgsUsage: "<txFile>",
+				Flags: []cli.Flag{
+					cli.StringFlag{Name: "keyfile", Usage: "key json `FILE`, defaults to the sender's file in the keydir"},
+					PasswordFlag,
+					OutputFlag,
+					HexFlag,
+				},
+			},
+			{
+				Name:      "decode",
+				Usage:     "Print a transaction in human readable form",
+				Action:    txDecode,
+				ArgsUsage: "<txFile>",
+				Description: `
+    gamc tx decode <txFile>
+
+Prints the fields of the transaction and verifies it: its hash must match
+its fields, its signer must be its from address, and the signature must be
+a valid signature of the hash by the signer public key.`,
+			},
+			{
+				Name:      "send",
+				Usage:     "Broadcast a signed transaction through a node",
+				Action:    txSend,
+				ArgsUsage: "<txFile>",
+				Flags: []cli.Flag{
+					cli.StringFlag{Name: "rpc", Usage: "rpc `ADDRESS` of the node, defaults to the rpc_listen of the config file"},
+				},
+			},
+		},
+	}
+)
+
+func txBuild(ctx *cli.Context) error {
+	if ctx.String("from") == "" || ctx.String("to") == "" {
+		return ErrMissingTxFrom
+	}
+	from, err := core.AddressParse(ctx.String("from"))
+	if err != nil {
+		return err
+	}
+	to, err := core.AddressParse(ctx.String("to"))
+	if err != nil {
+		return err
+	}
+	value, ok := new(big.Int).SetString(ctx.String("value"), 10)
+	if !ok || value.Sign() < 0 {
+		return ErrInvalidTxAmount
+	}
+	fee, ok := new(big.Int).SetString(ctx.String("fee"), 10)
+	if !ok || fee.Sign() < 0 {
+		return ErrInvalidTxAmount
+	}
+	payload, err := hex.DecodeString(ctx.String("payload"))
+	if err != nil {
+		return ErrInvalidTxPayload
+	}
+
+	chainId := uint32(ctx.Uint("chainid"))
+	if chainId == 0 {
+		config, err := loadConfig(ctx)
+		if err != nil {
+			return err
+		}
+		chainId = conf.GetChainConfig(config).ChainId
+	}
+
+	tx := new(core.Transaction)
+	if err := tx.FromProto(&corepb.Transaction{
+		From:      from.Bytes(),
+		To:        to.Bytes(),
+		Value:     value.Bytes(),
+		Nonce:     ctx.Uint64("nonce"),
+		ChainId:   chainId,
+		Fee:       fee.Bytes(),
+		Timestamp: time.Now().Unix(),
+		Data:      &corepb.Data{Type: ctx.String("type"), Msg: payload},
+		Priority:  uint32(ctx.Uint("priority")),
+	}); err != nil {
+		return err
+	}
+	return writeTx(ctx, tx)
+}
+
+func txSign(ctx *cli.Context) error {
+	tx, err := readTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	keyfile := ctx.String("keyfile")
+	if keyfile == "" {
+		config, err := loadConfig(ctx)
+		if err != nil {
+			return err
+		}
+		keyfile = filepath.Join(conf.GetChainConfig(config).Keydir, tx.From().String())
+	}
+	keyjson, err := ioutil.ReadFile(keyfile)
+	if err != nil {
+		return err
+	}
+	passwords, err := passwordList(ctx)
+	if err != nil {
+		return err
+	}
+	passphrase, err := getPassPhrase("Please give the passphrase of the key file.", false, 0, passwords)
+	if err != nil {
+		return err
+	}
+
+	data, err := cipher.NewCipher(uint8(keystore.SCRYPT)).DecryptKey(keyjson, passphrase)
+	if err != nil {
+		return err
+	}
+	priv, err := crypto.NewPrivateKey(data)
+	if err != nil {
+		return err
+	}
+	defer priv.Clear()
+
+	pub, err := priv.PublicKey().Encoded()
+	if err != nil {
+		return err
+	}
+	signer, err := core.NewAddressFromPublicKey(pub)
+	if err != nil {
+		return err
+	}
+	if !signer.Equals(tx.From()) {
+		return ErrKeyNotMatchFrom
+	}
+
+	signature, err := crypto.NewSignature()
+	if err != nil {
+		return err
+	}
+	if err := signature.InitSign(priv); err != nil {
+		return err
+	}
+	if err := tx.Sign(signature); err != nil {
+		return err
+	}
+	return writeTx(ctx, tx)
+}
+
+func txDecode(ctx *cli.Context) error {
+	tx, err := readTx(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Hash:      %s\n", tx.Hash())
+	fmt.Printf("From:      %s\n", tx.From())
+	fmt.Printf("To:        %s\n", tx.To())
+	fmt.Printf("Value:     %s\n", tx.Value())
+	fmt.Printf("Fee:       %s\n", tx.Fee())
+	fmt.Printf("Nonce:     %d\n", tx.Nonce())
+	fmt.Printf("ChainId:   %d\n", tx.ChainId())
+	fmt.Printf("Priority:  %d\n", tx.Priority())
+	fmt.Printf("Timestamp: %d (%s)\n", tx.Timestamp(), time.Unix(tx.Timestamp(), 0).UTC().Format(time.RFC3339))
+	if data := tx.Data(); data != nil {
+		fmt.Printf("Type:      %s\n", data.Type)
+		fmt.Printf("Payload:   %s\n", hex.EncodeToString(data.Msg))
+	}
+
+	sign := tx.Signature()
+	if sign == nil {
+		fmt.Println("Signer:    (unsigned)")
+		return nil
+	}
+	signer, err := core.NewAddressFromPublicKey(sign.Signer)
+	if err != nil {
+		fmt.Printf("Signer:    invalid (%v)\n", err)
+	} else {
+		fmt.Printf("Signer:    %s\n", signer)
+	}
+	// checks the hash, the signer and the signature of the hash.
+	if err := tx.VerifyIntegrity(tx.ChainId()); err != nil {
+		fmt.Printf("Verified:  false (%v)\n", err)
+	} else {
+		fmt.Println("Verified:  true")
+	}
+	return nil
+}
+
+func txSend(ctx *cli.Context) error {
+	if len(ctx.Args()) == 0 {
+		return ErrMissingTxFile
+	}
+	data, err := readTxData(ctx.Args().First())
+	if err != nil {
+		return err
+	}
+
+	endpoint := ctx.String("rpc")
+	if endpoint == "" {
+		config, err := loadConfig(ctx)
+		if err != nil {
+			return err
+		}
+		if listen := rpc.GetRpcConfig(config).RpcListen; len(listen) > 0 {
+			endpoint = listen[0]
+		}
+	}
+	if endpoint == "" {
+		return ErrNoRpcEndpoint
+	}
+
+	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := rpcpb.NewApiServiceClient(conn).SendRawTransaction(timeout, &rpcpb.SendRawTransactionRequest{Data: data})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Transaction hash: %s\n", resp.Txhash)
+	return nil
+}
+
+// readTxData read the raw transaction bytes from the file, hex encoded files are decoded.
+func readTxData(path string) ([]byte, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// a raw transaction starts with a field tag, which is never a hex digit.
+	if text := strings.TrimSpace(string(data)); len(text) > 0 {
+		if raw, err := byteutils.FromHex(text); err == nil {
+			return raw, nil
+		}
+	}
+	return data, nil
+}
+
+func readTx(ctx *cli.Context) (*core.Transaction, error) {
+	if len(ctx.Args()) == 0 {
+		return nil, ErrMissingTxFile
+	}
+	data, err := readTxData(ctx.Args().First())
+	if err != nil {
+		return nil, err
+	}
+	pbTx := new(corepb.Transaction)
+	if err := proto.Unmarshal(data, pbTx); err != nil {
+		return nil, err
+	}
+	tx := new(core.Transaction)
+	if err := tx.FromProto(pbTx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func writeTx(ctx *cli.Context, tx *core.Transaction) error {
+	pbTx, err := tx.ToProto()
+	if err != nil {
+		return err
+	}
+	data, err := proto.Marshal(pbTx)
+	if err != nil {
+		return err
+	}
+
+	output := ctx.String("output")
+	if output == "" {
+		fmt.Println(hex.EncodeToString(data))
+		return nil
+	}
+	if ctx.Bool(HexFlag.Name) {
+		data = []byte(hex.EncodeToString(data))
+	}
+	return ioutil.WriteFile(output, data, 0600)
+}
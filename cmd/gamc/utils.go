@@ -0,0 +1,117 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/ssh/terminal"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+var (
+	ErrPassphraseMismatch = errors.New("passphrases do not match")
+	ErrEmptyPassphrase    = errors.New("passphrase can't be empty")
+	ErrPasswordsExhausted = errors.New("not enough passphrases in the password file")
+)
+
+var (
+	// PasswordFlag password file for non-interactive use
+	PasswordFlag = cli.StringFlag{
+		Name:  "password",
+		Usage: "read passphrases from `FILE`, one per line, instead of prompting",
+	}
+
+	stdinReader = bufio.NewReader(os.Stdin)
+)
+
+// passwordList returns the lines of the password file, or nil if no file is given.
+func passwordList(ctx *cli.Context) ([]string, error) {
+	path := ctx.String(PasswordFlag.Name)
+	if path == "" {
+		return nil, nil
+	}
+	text, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password file: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(string(text), "\r\n"), "\n")
+	// sanitise DOS line endings.
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], "\r")
+	}
+	return lines, nil
+}
+
+// getPassPhrase returns the i-th passphrase of the password file, or
+// prompts for one if no password file is given.
+func getPassPhrase(prompt string, confirmation bool, i int, passwords []string) ([]byte, error) {
+	if passwords != nil {
+		if i >= len(passwords) {
+			return nil, ErrPasswordsExhausted
+		}
+		if len(passwords[i]) == 0 {
+			return nil, ErrEmptyPassphrase
+		}
+		return []byte(passwords[i]), nil
+	}
+
+	if prompt != "" {
+		fmt.Fprintln(os.Stderr, prompt)
+	}
+	passphrase, err := readSecret("Passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+	if confirmation {
+		confirm, err := readSecret("Repeat passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(passphrase, confirm) {
+			return nil, ErrPassphraseMismatch
+		}
+	}
+	return passphrase, nil
+}
+
+// readSecret reads a line from stdin without echo if it's a terminal.
+func readSecret(prompt string) ([]byte, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	fd := int(os.Stdin.Fd())
+	if terminal.IsTerminal(fd) {
+		secret, err := terminal.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		return secret, err
+	}
+
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && len(line) == 0 {
+		return nil, err
+	}
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
@@ -36,6 +36,9 @@ type ChainConfig struct {
 	Miner     string   `yaml:"miner"`
 	Genesis   string   `yaml:"genesis"`
 	Witnesses []string `yaml:"witnesses"`
+	// NoConsensus run the node without a consensus engine, the blocks are
+	// accepted without the consensus check. For development only.
+	NoConsensus bool `yaml:"no_consensus"`
 }
 
 func GetChainConfig(conf *config.Config) *ChainConfig {
@@ -52,7 +52,7 @@ type Account interface {
 	PledgeFund() *big.Int
 	Nonce() uint64
 	CreditIndex() *big.Int
-	VarsHash() byteutils.Hash
+	VarsHash() (byteutils.Hash, error)
 	Clone() (Account, error)
 
 	ToBytes() ([]byte, error)
@@ -75,10 +75,11 @@ type Account interface {
 
 // AccountState Interface
 type AccountState interface {
-	RootHash() byteutils.Hash
+	RootHash() (byteutils.Hash, error)
 
 	Flush() error
 	Abort() error
+	Commit() error
 
 	DirtyAccounts() ([]Account, error)
 	Accounts() ([]Account, error)
@@ -103,6 +104,10 @@ type account struct {
 
 // ToBytes converts domain Account to bytes
 func (acc *account) ToBytes() ([]byte, error) {
+	varsHash, err := acc.variables.RootHash()
+	if err != nil {
+		return nil, err
+	}
 	pbAcc := &corepb.Account{
 		Address:    acc.address,
 		Balance:    acc.balance.Bytes(),
@@ -110,7 +115,7 @@ func (acc *account) ToBytes() ([]byte, error) {
 		PledgeFund: acc.frozenFund.Bytes(),
 		Nonce:      acc.nonce,
 
-		VarsHash:    acc.variables.RootHash(),
+		VarsHash:    varsHash,
 		CreditIndex: acc.creditIndex.Bytes(),
 		Permissions: acc.permissions,
 	}
@@ -173,7 +178,7 @@ func (acc *account) CreditIndex() *big.Int {
 }
 
 // VarsHash return account's variables hash
-func (acc *account) VarsHash() byteutils.Hash {
+func (acc *account) VarsHash() (byteutils.Hash, error) {
 	return acc.variables.RootHash()
 }
 
@@ -273,8 +278,7 @@ func (acc *account) SubCreditIndex(value *big.Int) error {
 
 // Put into account's storage
 func (acc *account) Put(key []byte, value []byte) error {
-	_, err := acc.variables.Put(key, value)
-	return err
+	return acc.variables.Put(key, value)
 }
 
 // Get from account's storage
@@ -284,7 +288,7 @@ func (acc *account) Get(key []byte) ([]byte, error) {
 
 // Del from account's storage
 func (acc *account) Del(key []byte) error {
-	if _, err := acc.variables.Del(key); err != nil {
+	if err := acc.variables.Del(key); err != nil {
 		return err
 	}
 	return nil
@@ -296,6 +300,7 @@ func (acc *account) Iterator(prefix []byte) (Iterator, error) {
 }
 
 func (acc *account) String() string {
+	varsHash, _ := acc.variables.RootHash()
 	return fmt.Sprintf("Account %p {Address: %v, Balance:%v, FrozenFund:%v, PledgeFund:%v, CreditIndex:%v; Nonce:%v; VarsHash:%v;}",
 		acc,
 		byteutils.Hex(acc.address),
@@ -304,7 +309,7 @@ func (acc *account) String() string {
 		acc.pledgeFund.String(),
 		acc.creditIndex,
 		acc.nonce,
-		byteutils.Hex(acc.variables.RootHash()),
+		byteutils.Hex(varsHash),
 	)
 }
 
@@ -322,7 +327,11 @@ func NewAccountState(root byteutils.Hash, storage cdb.Storage) (AccountState, er
 	}, nil
 }
 
+// Flush the dirty accounts into the state trie, writing the nodes of their storage.
 func (as *accountState) Flush() error {
+	if err := as.commitAccounts(); err != nil {
+		return err
+	}
 	for addr, acc := range as.dirtyAccount {
 		bytes, err := acc.ToBytes()
 		if err != nil {
@@ -338,13 +347,28 @@ func (as *accountState) Flush() error {
 	return nil
 }
 
+// commitAccounts write the nodes of the storage of the dirty accounts.
+func (as *accountState) commitAccounts() error {
+	for _, acc := range as.dirtyAccount {
+		if err := acc.(*account).variables.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit write the nodes of the state trie, the dirty accounts are flushed first.
+func (as *accountState) Commit() error {
+	return as.stateTrie.Commit()
+}
+
 func (as *accountState) Abort() error {
 	as.dirtyAccount = make(map[byteutils.HexHash]Account)
 	return nil
 }
 
 // RootHash return root hash of account state
-func (as *accountState) RootHash() byteutils.Hash {
+func (as *accountState) RootHash() (byteutils.Hash, error) {
 	return as.stateTrie.RootHash()
 }
 
@@ -476,10 +500,37 @@ func (as *accountState) getAccount(addr byteutils.Hash) (Account, error) {
 	return nil, ErrAccountNotFound
 }
 
+// savepoint return copies of the dirty accounts, to restore them by rollBackTo.
+func (as *accountState) savepoint() (map[byteutils.HexHash]*account, error) {
+	saved := make(map[byteutils.HexHash]*account)
+	for addr, acc := range as.dirtyAccount {
+		cloned, err := acc.Clone()
+		if err != nil {
+			return nil, err
+		}
+		saved[addr] = cloned.(*account)
+	}
+	return saved, nil
+}
+
+// rollBackTo restore the dirty accounts saved by savepoint in place, so the
+// accounts got before the savepoint stay valid. The accounts got after it are
+// dropped and must be got again.
+func (as *accountState) rollBackTo(saved map[byteutils.HexHash]*account) {
+	for addr, acc := range as.dirtyAccount {
+		if prev, ok := saved[addr]; ok {
+			*acc.(*account) = *prev
+		} else {
+			delete(as.dirtyAccount, addr)
+		}
+	}
+}
+
 func (as *accountState) String() string {
+	rootHash, _ := as.stateTrie.RootHash()
 	return fmt.Sprintf("AccountState %p {RootHash:%s; dirtyAccount:%v; Storage:%p}",
 		as,
-		byteutils.Hex(as.stateTrie.RootHash()),
+		byteutils.Hex(rootHash),
 		as.dirtyAccount,
 		as.storage,
 	)
@@ -190,8 +190,8 @@ func (am *AddressManager) exportFile(addr *core.Address, passphrase []byte, over
 	}
 
 	acc, err := am.getAddressInfo(addr)
-	// acc not found
-	if err != nil {
+	// acc not found or not saved yet
+	if err != nil || len(acc.path) == 0 {
 		path = filepath.Join(am.keydir, addr.String())
 	} else {
 		path = acc.path
@@ -235,7 +235,7 @@ func (am *AddressManager) Import(keyjson, passphrase []byte) (*core.Address, err
 
 // Export export address to key file
 func (am *AddressManager) Export(addr *core.Address, passphrase []byte) ([]byte, error) {
-	key, err := am.ks.GetKey(addr.String(), passphrase)
+	key, err := am.getKey(addr, passphrase)
 	if err != nil {
 		return nil, err
 	}
@@ -258,16 +258,48 @@ func (am *AddressManager) Export(addr *core.Address, passphrase []byte) ([]byte,
 	return out, nil
 }
 
-// Remove remove address and encrypted private key from keystore
+// Remove remove address and encrypted private key from keystore and keydir
 func (am *AddressManager) RemoveAddress(addr *core.Address, passphrase []byte) error {
-	err := am.ks.Delete(addr.String(), passphrase)
+	key, err := am.getKey(addr, passphrase)
 	if err != nil {
 		return err
 	}
+	key.Clear()
+
+	err = am.ks.Delete(addr.String(), passphrase)
+	if err != nil {
+		return err
+	}
+
+	am.mu.Lock()
+	defer am.mu.Unlock()
 
+	for i, address := range am.addresses {
+		if address.addr.Equals(addr) {
+			if len(address.path) > 0 {
+				if err := os.Remove(address.path); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+			}
+			am.addresses = append(am.addresses[:i], am.addresses[i+1:]...)
+			break
+		}
+	}
 	return nil
 }
 
+// getKey returns the key of addr, loading it from the key file when it's not in keystore yet
+func (am *AddressManager) getKey(addr *core.Address, passphrase []byte) (keystore.Key, error) {
+	key, err := am.ks.GetKey(addr.String(), passphrase)
+	if err != nil {
+		if err := am.loadFile(addr, passphrase); err != nil {
+			return nil, err
+		}
+		return am.ks.GetKey(addr.String(), passphrase)
+	}
+	return key, nil
+}
+
 func (am *AddressManager) getAddressInfo(addr *core.Address) (*addressInfo, error) {
 	am.mu.Lock()
 	defer am.mu.Unlock()
@@ -334,16 +366,9 @@ func (am *AddressManager) LoadPrivate(privatekey, passphrase []byte) (*core.Addr
 
 // Update update addr locked passphrase
 func (am *AddressManager) UpdatePassphrase(addr *core.Address, oldPassphrase, newPassphrase []byte) error {
-	key, err := am.ks.GetKey(addr.String(), oldPassphrase)
+	key, err := am.getKey(addr, oldPassphrase)
 	if err != nil {
-		err = am.loadFile(addr, oldPassphrase)
-		if err != nil {
-			return err
-		}
-		key, err = am.ks.GetKey(addr.String(), oldPassphrase)
-		if err != nil {
-			return err
-		}
+		return err
 	}
 	defer key.Clear()
 
@@ -389,7 +414,7 @@ func (am *AddressManager) SignHash(addr *core.Address, hash byteutils.Hash) ([]b
 
 // GetMnemonic
 func (am *AddressManager) GetMnemonic(addr *core.Address, passphrase []byte) (string, error) {
-	key, err := am.ks.GetKey(addr.String(), passphrase)
+	key, err := am.getKey(addr, passphrase)
 	if err != nil {
 		return "", err
 	}
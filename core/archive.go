@@ -0,0 +1,206 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package core
+
+import (
+	corepb "gamc.pro/gamcio/go-gamc/core/pb"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"github.com/gogo/protobuf/proto"
+	"io"
+)
+
+// Block archive layout, all integers are big endian:
+//
+//	magic      [8]byte "GAMCARCH"
+//	version    uint32
+//	chainId    uint32
+//	genesis    uint32 length + genesis hash
+//	blocks     uint32 length + marshaled corepb.Block, repeated
+//
+// The whole archive may be gzip compressed.
+const (
+	ArchiveVersion = 1
+
+	// MaxArchiveRecordSize the max size of a record in the archive.
+	MaxArchiveRecordSize = 128 * 1024 * 1024
+)
+
+var (
+	archiveMagic = []byte("GAMCARCH")
+	gzipMagic    = []byte{0x1f, 0x8b}
+)
+
+var (
+	ErrInvalidArchive         = errors.New("invalid block archive")
+	ErrUnsupportedArchive     = errors.New("unsupported block archive version")
+	ErrArchiveChainIDMismatch = errors.New("block archive belongs to another chain")
+	ErrArchiveGenesisMismatch = errors.New("block archive has a different genesis block")
+	ErrArchiveRecordTooLarge  = errors.New("block archive record is too large")
+	ErrArchiveBlockNotFound   = errors.New("block to export is not found on canonical chain")
+	ErrInvalidArchiveRange    = errors.New("invalid height range to export")
+)
+
+// ArchiveProgress is called after each block is exported or imported.
+type ArchiveProgress func(block *Block)
+
+// ExportArchive write the canonical blocks in [from, to] to w, gzip compressed if compress is true.
+func (bc *BlockChain) ExportArchive(w io.Writer, from, to uint64, compress bool, progress ArchiveProgress) error {
+	if from > to || to > bc.TailBlock().Height() {
+		return ErrInvalidArchiveRange
+	}
+
+	var zw *gzip.Writer
+	if compress {
+		zw = gzip.NewWriter(w)
+		w = zw
+	}
+	bw := bufio.NewWriter(w)
+
+	header := new(bytes.Buffer)
+	header.Write(archiveMagic)
+	header.Write(byteutils.FromUint32(ArchiveVersion))
+	header.Write(byteutils.FromUint32(bc.ChainId()))
+	if _, err := bw.Write(header.Bytes()); err != nil {
+		return err
+	}
+	if err := writeArchiveRecord(bw, bc.GenesisBlock().Hash()); err != nil {
+		return err
+	}
+
+	for height := from; height <= to; height++ {
+		block := bc.GetBlockOnCanonicalChainByHeight(height)
+		if block == nil {
+			return ErrArchiveBlockNotFound
+		}
+		pbBlock, err := block.ToProto()
+		if err != nil {
+			return err
+		}
+		data, err := proto.Marshal(pbBlock)
+		if err != nil {
+			return err
+		}
+		if err := writeArchiveRecord(bw, data); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(block)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	if zw != nil {
+		return zw.Close()
+	}
+	return nil
+}
+
+// ImportArchive read blocks from the archive and push them into the block pool.
+// Blocks already on chain are skipped, progress is not called for them.
+func (bc *BlockChain) ImportArchive(r io.Reader, progress ArchiveProgress) error {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		br = bufio.NewReader(zr)
+	}
+
+	header := make([]byte, len(archiveMagic)+8)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return ErrInvalidArchive
+	}
+	if !bytes.Equal(header[:len(archiveMagic)], archiveMagic) {
+		return ErrInvalidArchive
+	}
+	if byteutils.Uint32(header[len(archiveMagic):]) != ArchiveVersion {
+		return ErrUnsupportedArchive
+	}
+	if byteutils.Uint32(header[len(archiveMagic)+4:]) != bc.ChainId() {
+		return ErrArchiveChainIDMismatch
+	}
+	genesis, err := readArchiveRecord(br)
+	if err != nil {
+		return err
+	}
+	if !bc.GenesisBlock().Hash().Equals(genesis) {
+		return ErrArchiveGenesisMismatch
+	}
+
+	for {
+		data, err := readArchiveRecord(br)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		pbBlock := new(corepb.Block)
+		if err := proto.Unmarshal(data, pbBlock); err != nil {
+			return err
+		}
+		block := new(Block)
+		if err := block.FromProto(pbBlock); err != nil {
+			return err
+		}
+		if bc.GetBlock(block.Hash()) != nil {
+			continue
+		}
+		if err := bc.BlockPool().Push(block); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(block)
+		}
+	}
+}
+
+func writeArchiveRecord(w io.Writer, data []byte) error {
+	if _, err := w.Write(byteutils.FromUint32(uint32(len(data)))); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// readArchiveRecord returns io.EOF only if the archive ends right before a record.
+func readArchiveRecord(r io.Reader) ([]byte, error) {
+	size := make([]byte, 4)
+	if _, err := io.ReadFull(r, size); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, ErrInvalidArchive
+	}
+	length := byteutils.Uint32(size)
+	if length > MaxArchiveRecordSize {
+		return nil, ErrArchiveRecordTooLarge
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, ErrInvalidArchive
+	}
+	return data, nil
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"bytes"
+	"gamc.pro/gamcio/go-gamc/network"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/util/config"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testGamc struct {
+	config  *config.Config
+	storage cdb.Storage
+	chain   *BlockChain
+}
+
+func (g *testGamc) BlockChain() *BlockChain        { return g.chain }
+func (g *testGamc) NetService() network.Service    { return nil }
+func (g *testGamc) AccountManager() AccountManager { return nil }
+func (g *testGamc) Consensus() Consensus           { return NewNoConsensus() }
+func (g *testGamc) Config() *config.Config         { return g.config }
+func (g *testGamc) Storage() cdb.Storage           { return g.storage }
+
+func testAddress(t *testing.T, seed string) *Address {
+	addr, err := newAddress(AccountAddress, []byte(seed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+// testConfig writes a config file into dir and loads it.
+func testConfig(t *testing.T, dir string) *config.Config {
+	path := filepath.Join(dir, "conf.yaml")
+	if err := ioutil.WriteFile(path, []byte("chain:\n chain_id: 23\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.InitConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// newTestChain return a chain on an in-memory storage, with an empty genesis
+// block stored as GenesisHash, so that no genesis file is needed.
+func newTestChain(t *testing.T, cfg *config.Config) *BlockChain {
+	storage, _ := cdb.NewMemoryStorage()
+	chain, err := NewBlockChain(cfg, nil, storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	worldState, err := newWorldState(chain.chainTables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	accountsRoot, _ := worldState.AccountsRoot()
+	txsRoot, _ := worldState.TxsRoot()
+	genesis := &Block{
+		header: &BlockHeader{
+			chainId:       chain.ChainId(),
+			witnessreward: big.NewInt(0),
+			coinbase:      testAddress(t, "coinbase"),
+			stateRoot:     accountsRoot,
+			txsRoot:       txsRoot,
+			psecData:      &PsecData{term: 0, timestamp: GenesisTimestamp},
+			timestamp:     GenesisTimestamp,
+		},
+		worldState: worldState,
+		tables:     chain.chainTables,
+	}
+	// the test genesis stands for the genesis of the chain, with a valid hash.
+	genesis.header.hash = genesis.CalcHash()
+	GenesisHash = genesis.header.hash
+	if err := chain.StoreBlockToStorage(genesis); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := chain.Setup(&testGamc{config: cfg, storage: storage, chain: chain}); err != nil {
+		t.Fatal(err)
+	}
+	return chain
+}
+
+// newTestBlock return an empty block on top of parent.
+func newTestBlock(t *testing.T, parent *Block) *Block {
+	header := &BlockHeader{
+		chainId:       parent.header.chainId,
+		witnessreward: big.NewInt(0),
+		coinbase:      testAddress(t, "coinbase"),
+		stateRoot:     parent.StateRoot(),
+		txsRoot:       parent.TxsRoot(),
+		parentHash:    parent.Hash(),
+		psecData:      &PsecData{term: 0, timestamp: parent.Timestamp() + 15},
+		height:        parent.Height() + 1,
+		timestamp:     parent.Timestamp() + 15,
+	}
+	block := NewBlock(header, nil)
+	header.hash = block.CalcHash()
+	return block
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := testConfig(t, dir)
+
+	src := newTestChain(t, cfg)
+	for i := 0; i < 5; i++ {
+		if err := src.BlockPool().Push(newTestBlock(t, src.TailBlock())); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if src.TailBlock().Height() != 5 {
+		t.Fatalf("source tail height %d, want 5", src.TailBlock().Height())
+	}
+
+	archive := new(bytes.Buffer)
+	if err := src.ExportArchive(archive, 1, src.TailBlock().Height(), true, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := newTestChain(t, cfg)
+	imported := 0
+	progress := func(block *Block) { imported++ }
+	if err := dst.ImportArchive(bytes.NewReader(archive.Bytes()), progress); err != nil {
+		t.Fatal(err)
+	}
+	if imported != 5 {
+		t.Fatalf("imported %d blocks, want 5", imported)
+	}
+	if !dst.TailBlock().Hash().Equals(src.TailBlock().Hash()) {
+		t.Fatalf("imported tail %s, want %s", dst.TailBlock().Hash(), src.TailBlock().Hash())
+	}
+	for height := uint64(1); height <= 5; height++ {
+		want := src.GetBlockOnCanonicalChainByHeight(height)
+		got := dst.GetBlockOnCanonicalChainByHeight(height)
+		if got == nil || !got.Hash().Equals(want.Hash()) {
+			t.Fatalf("block at height %d differs after import", height)
+		}
+	}
+
+	// the blocks are on chain now, importing them again skips all of them.
+	imported = 0
+	if err := dst.ImportArchive(bytes.NewReader(archive.Bytes()), progress); err != nil {
+		t.Fatal(err)
+	}
+	if imported != 0 {
+		t.Fatalf("imported %d blocks already on chain", imported)
+	}
+}
@@ -20,7 +20,6 @@ package core
 import (
 	corepb "gamc.pro/gamcio/go-gamc/core/pb"
 	"gamc.pro/gamcio/go-gamc/crypto/keystore"
-	"gamc.pro/gamcio/go-gamc/storage/cdb"
 	"gamc.pro/gamcio/go-gamc/util/byteutils"
 	"gamc.pro/gamcio/go-gamc/util/logging"
 	"github.com/gogo/protobuf/proto"
@@ -38,7 +37,7 @@ type Block struct {
 	header       *BlockHeader
 	transactions []*Transaction //
 	worldState   WorldState
-	db           cdb.Storage
+	tables       *chainTables
 }
 
 // NewBlock
@@ -89,7 +88,7 @@ func LoadBlockFromStorage(hash byteutils.Hash, chain *BlockChain) (*Block, error
 		return nil, ErrNilArgument
 	}
 
-	value, err := chain.db.Get(hash)
+	value, err := chain.blocks.Get(hash)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +100,7 @@ func LoadBlockFromStorage(hash byteutils.Hash, chain *BlockChain) (*Block, error
 	if err = block.FromProto(pbBlock); err != nil {
 		return nil, err
 	}
-	block.worldState, err = NewWorldState(chain.db)
+	block.worldState, err = newWorldState(chain.chainTables)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +111,7 @@ func LoadBlockFromStorage(hash byteutils.Hash, chain *BlockChain) (*Block, error
 		return nil, err
 	}
 
-	block.db = chain.db
+	block.tables = chain.chainTables
 	return block, nil
 }
 
@@ -297,7 +296,7 @@ func (b *Block) LinkParentBlock(chain *BlockChain, parentBlock *Block) error {
 	//block.WorldState().SetConsensusState(consensusState)
 
 	b.header.height = parentBlock.header.height + 1
-	b.db = parentBlock.db
+	b.tables = parentBlock.tables
 
 	return nil
 }
@@ -450,19 +449,27 @@ func (b *Block) RollBack() {
 // verifyState return state verify result.
 func (b *Block) verifyState() error {
 	// verify state root.
-	if !byteutils.Equal(b.WorldState().AccountsRoot(), b.StateRoot()) {
+	accountsRoot, err := b.WorldState().AccountsRoot()
+	if err != nil {
+		return err
+	}
+	if !byteutils.Equal(accountsRoot, b.StateRoot()) {
 		logging.VLog().WithFields(logrus.Fields{
 			"expect": b.StateRoot(),
-			"actual": b.WorldState().AccountsRoot(),
+			"actual": accountsRoot,
 		}).Info("Failed to verify state.")
 		return ErrInvalidBlockStateRoot
 	}
 
 	// verify transaction root.
-	if !byteutils.Equal(b.WorldState().TxsRoot(), b.TxsRoot()) {
+	txsRoot, err := b.WorldState().TxsRoot()
+	if err != nil {
+		return err
+	}
+	if !byteutils.Equal(txsRoot, b.TxsRoot()) {
 		logging.VLog().WithFields(logrus.Fields{
 			"expect": b.TxsRoot(),
-			"actual": b.WorldState().TxsRoot(),
+			"actual": txsRoot,
 		}).Info("Failed to verify txs.")
 		return ErrInvalidBlockTxsRoot
 	}
@@ -470,9 +477,19 @@ func (b *Block) verifyState() error {
 	return nil
 }
 
-// Commit a batch task
+// Commit a batch task, writing the world state and the block itself, once its
+// hash is known. The block is written in one batch with the last trie nodes,
+// after the others when they exceed the ideal batch size of the storage.
 func (b *Block) Commit() {
-	if err := b.WorldState().Commit(); err != nil {
+	batch := newTrieBatch(b.tables)
+	err := b.WorldState().CommitTo(batch)
+	if err == nil && b.Hash() != nil {
+		err = batch.last().putBlock(b)
+	}
+	if err == nil {
+		err = batch.Write()
+	}
+	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
 			"err": err,
 		}).Fatal("Failed to commit the block")
@@ -509,6 +526,30 @@ func (b *Block) Coinbase() *Address {
 	return b.header.coinbase
 }
 
+// Snapshot return the read-only states at this block, safe for concurrent reads.
+func (b *Block) Snapshot() (Snapshot, error) {
+	return newSnapshot(b.tables.tries, b.StateRoot(), b.TxsRoot())
+}
+
+// GetAccount return the account state of the address at this block.
+func (b *Block) GetAccount(address byteutils.Hash) (Account, error) {
+	snapshot, err := b.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.GetOrCreateAccount(address)
+}
+
+// GetTransaction return the transaction with the given hash in this block.
+func (b *Block) GetTransaction(hash byteutils.Hash) *Transaction {
+	for _, tx := range b.transactions {
+		if tx.Hash().Equals(hash) {
+			return tx
+		}
+	}
+	return nil
+}
+
 // Transactions returns block transactions
 func (b *Block) Transactions() Transactions {
 	return b.transactions
@@ -38,8 +38,55 @@ const (
 	Tail = "blockchain_tail"
 	// Fixed in storage
 	FIXED = "blockchain_fixed"
+
+	// BlockTable is the table of the blocks by hash
+	BlockTable = "b"
+	// MetaTable is the table of the chain pointers, like Tail and FIXED
+	MetaTable = "m"
+	// HeightTable is the table of the canonical block hash by height
+	HeightTable = "h"
+	// TxIndexTable is the table of the canonical block hash by tx hash
+	TxIndexTable = "t"
+	// TrieTable is the table of the trie nodes of the states and txs
+	TrieTable = "s"
 )
 
+// chainTables the tables sharing the chain storage.
+type chainTables struct {
+	blocks    cdb.Storage
+	meta      cdb.Storage
+	heights   cdb.Storage
+	txIndices cdb.Storage
+	tries     cdb.Storage
+
+	// the storage shared by the tables.
+	shared cdb.Storage
+}
+
+func newChainTables(db cdb.Storage) *chainTables {
+	return &chainTables{
+		shared:    db,
+		blocks:    cdb.NewTable(db, BlockTable),
+		meta:      cdb.NewTable(db, MetaTable),
+		heights:   cdb.NewTable(db, HeightTable),
+		txIndices: cdb.NewTable(db, TxIndexTable),
+		tries:     cdb.NewTable(db, TrieTable),
+	}
+}
+
+// newCachedChainTables returns the tables of db, with the blocks and the trie
+// nodes cached as configured in dbcfg.
+func newCachedChainTables(db cdb.Storage, dbcfg *cdb.DbConfig) *chainTables {
+	tables := newChainTables(db)
+	if dbcfg.BlockCache > 0 {
+		tables.blocks = cdb.NewCachedStorage(tables.blocks, "block", dbcfg.BlockCache)
+	}
+	if dbcfg.TrieCache > 0 {
+		tables.tries = cdb.NewCachedStorage(tables.tries, "trie", dbcfg.TrieCache)
+	}
+	return tables
+}
+
 // BlockChain
 type BlockChain struct {
 	chainId            uint32
@@ -56,7 +103,11 @@ type BlockChain struct {
 	fixedBlock         *Block
 	cachedBlocks       *lru.Cache
 	detachedTailBlocks *lru.Cache
+	eventEmitter       *EventEmitter
 	quitCh             chan int
+
+	// the tables of db.
+	*chainTables
 }
 
 // NewBlockChain
@@ -69,16 +120,25 @@ func NewBlockChain(config *config.Config, net network.Service, db cdb.Storage) (
 
 	chaincfg := conf.GetChainConfig(config)
 	txPool := NewTxPool()
+	eventEmitter := NewEventEmitter()
+	txPool.setEventEmitter(eventEmitter)
 
 	chain := &BlockChain{
-		chainId: chaincfg.ChainId,
-		config:  config,
-		db:      db,
-		bkPool:  blockPool,
-		txPool:  txPool,
+		chainId:      chaincfg.ChainId,
+		config:       config,
+		db:           db,
+		chainTables:  newCachedChainTables(db, cdb.GetDbConfig(config)),
+		bkPool:       blockPool,
+		txPool:       txPool,
+		eventEmitter: eventEmitter,
+		quitCh:       make(chan int, 1),
 	}
 
-	blockPool.RegisterInNetwork(net)
+	// offline tools run the chain without network.
+	if net != nil {
+		blockPool.RegisterInNetwork(net)
+		txPool.RegisterInNetwork(net)
+	}
 
 	chain.cachedBlocks, err = lru.New(128)
 	if err != nil {
@@ -91,6 +151,7 @@ func NewBlockChain(config *config.Config, net network.Service, db cdb.Storage) (
 	}
 
 	chain.bkPool.setBlockChain(chain)
+	chain.txPool.setBlockChain(chain)
 
 	return chain, nil
 }
@@ -136,7 +197,7 @@ func (bc *BlockChain) LoadGenesisFromStorage() (*Block, error) { // ToRefine, re
 			return nil, err
 		}
 		heightKey := byteutils.FromUint64(genesis.Height())
-		if err := bc.db.Put(heightKey, genesis.Hash()); err != nil {
+		if err := bc.heights.Put(heightKey, genesis.Hash()); err != nil {
 			return nil, err
 		}
 	}
@@ -145,7 +206,7 @@ func (bc *BlockChain) LoadGenesisFromStorage() (*Block, error) { // ToRefine, re
 
 // LoadTailFromStorage load tail block
 func (bc *BlockChain) LoadTailFromStorage() (*Block, error) {
-	hash, err := bc.db.Get([]byte(Tail))
+	hash, err := bc.meta.Get([]byte(Tail))
 	if err != nil && err != cdb.ErrKeyNotFound {
 		return nil, err
 	}
@@ -162,16 +223,16 @@ func (bc *BlockChain) LoadTailFromStorage() (*Block, error) {
 		return genesis, nil
 	}
 
-	return LoadBlockFromStorage(hash, bc)
+	return bc.recoverTail(hash)
 }
 
 func (bc *BlockChain) StoreTailHashToStorage(block *Block) error {
-	return bc.db.Put([]byte(Tail), block.Hash())
+	return bc.meta.Put([]byte(Tail), block.Hash())
 }
 
 // LoadFixedFromStorage load FIXED
 func (bc *BlockChain) LoadFixedFromStorage() (*Block, error) {
-	hash, err := bc.db.Get([]byte(FIXED))
+	hash, err := bc.meta.Get([]byte(FIXED))
 	if err != nil && err != cdb.ErrKeyNotFound {
 		return nil, err
 	}
@@ -188,7 +249,7 @@ func (bc *BlockChain) LoadFixedFromStorage() (*Block, error) {
 
 // StoreFIXEDHashToStorage store FIXED block hash
 func (bc *BlockChain) StoreFIXEDHashToStorage(block *Block) error {
-	return bc.db.Put([]byte(FIXED), block.Hash())
+	return bc.meta.Put([]byte(FIXED), block.Hash())
 }
 
 func (bc *BlockChain) ChainId() uint32 {
@@ -200,28 +261,173 @@ func (bc *BlockChain) SetTailBlock(newTail *Block) error {
 		return ErrNilArgument
 	}
 
-	if bc.tailBlock != nil {
-		oldTail := bc.tailBlock
-		if oldTail.Height()+1 != newTail.Height() {
+	oldTail := bc.tailBlock
+	reverted, attached := make([]*Block, 0), []*Block{newTail}
+	if oldTail != nil {
+		if oldTail.Height() >= newTail.Height() {
 			return errors.New("not invalid tail block")
 		}
+		var err error
+		if reverted, attached, err = bc.findForkBranches(oldTail, newTail); err != nil {
+			return err
+		}
 	}
 
-	if err := bc.db.Put(byteutils.FromUint64(newTail.Height()), newTail.Hash()); err != nil {
-		logging.VLog().WithFields(logrus.Fields{
-			"newtail": newTail,
-		}).Debug("Failed to build index by block height.")
+	// commit the attached blocks, their indices and the tail at once. Drop the
+	// indices of the detached blocks first, the attached blocks may reuse their heights.
+	batch := bc.newBatch()
+	for _, v := range reverted {
+		if err := batch.dropIndices(v); err != nil {
+			return err
+		}
 	}
-
-	if err := bc.StoreTailHashToStorage(newTail); err != nil { // Refine: rename, delete ToStorage
+	for _, v := range attached {
+		if err := batch.putBlock(v); err != nil {
+			return err
+		}
+		if err := batch.buildIndices(v); err != nil {
+			return err
+		}
+	}
+	if err := batch.setTail(newTail); err != nil {
+		return err
+	}
+	if err := batch.write(); err != nil {
 		return err
 	}
 
 	bc.tailBlock = newTail
 
+	if len(reverted) > 0 {
+		logging.CLog().WithFields(logrus.Fields{
+			"oldtail":  oldTail,
+			"newtail":  newTail,
+			"reverted": len(reverted),
+		}).Info("Chain reorganized.")
+	}
+	for _, v := range reverted {
+		bc.eventEmitter.Trigger(&Event{Topic: TopicRevertBlock, Block: v})
+	}
+	for _, v := range attached {
+		bc.eventEmitter.Trigger(&Event{Topic: TopicNewTailBlock, Block: v})
+	}
+
 	return nil
 }
 
+// SetHead rewind the canonical chain to the block at height. The fixed block is
+// moved back too if it's above height, which is refused unless force is true.
+// The discarded blocks are deleted with their height and tx indices, so they
+// can be received and attached again, and their transactions are returned to
+// the tx pool. It returns the discarded blocks in descending order.
+func (bc *BlockChain) SetHead(height uint64, force bool) ([]*Block, error) {
+	// keep the block pool from moving the tail meanwhile.
+	bc.bkPool.mu.Lock()
+	defer bc.bkPool.mu.Unlock()
+
+	oldTail := bc.tailBlock
+	if height > oldTail.Height() {
+		return nil, ErrInvalidSetHeadHeight
+	}
+	if height < bc.fixedBlock.Height() && !force {
+		return nil, ErrSetHeadBelowFixed
+	}
+
+	reverted := make([]*Block, 0)
+	newTail := oldTail
+	for newTail.Height() > height {
+		reverted = append(reverted, newTail)
+		if newTail = bc.GetBlock(newTail.ParentHash()); newTail == nil {
+			return nil, ErrMissingParentBlock
+		}
+	}
+	if len(reverted) == 0 {
+		return reverted, nil
+	}
+
+	batch := bc.newBatch()
+	for _, v := range reverted {
+		if err := batch.dropIndices(v); err != nil {
+			return nil, err
+		}
+		if err := batch.dropBlock(v); err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.setTail(newTail); err != nil {
+		return nil, err
+	}
+	moveFixed := bc.fixedBlock.Height() > height
+	if moveFixed {
+		if err := batch.setFixed(newTail); err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.write(); err != nil {
+		return nil, err
+	}
+	for _, v := range reverted {
+		bc.cachedBlocks.Remove(v.Hash().Hex())
+		bc.detachedTailBlocks.Remove(v.Hash().Hex())
+	}
+	bc.tailBlock = newTail
+	if moveFixed {
+		bc.fixedBlock = newTail
+	}
+
+	txs := 0
+	for _, v := range reverted {
+		for _, err := range bc.txPool.AddLocal(v.Transactions()) {
+			logging.VLog().WithFields(logrus.Fields{
+				"block": v,
+				"err":   err,
+			}).Debug("Failed to return tx to tx pool.")
+		}
+		txs += len(v.Transactions())
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"oldtail":  oldTail,
+		"newtail":  newTail,
+		"reverted": len(reverted),
+		"txs":      txs,
+		"force":    force,
+	}).Info("Rewound chain.")
+	for _, v := range reverted {
+		bc.eventEmitter.Trigger(&Event{Topic: TopicRevertBlock, Block: v})
+	}
+	return reverted, nil
+}
+
+// findForkBranches return the blocks detached from the old tail, in descending order,
+// and the blocks attached up to the new tail, in ascending order.
+func (bc *BlockChain) findForkBranches(oldTail, newTail *Block) ([]*Block, []*Block, error) {
+	reverted, attached := make([]*Block, 0), make([]*Block, 0)
+
+	oldBlock, newBlock := oldTail, newTail
+	for newBlock.Height() > oldBlock.Height() {
+		attached = append(attached, newBlock)
+		if newBlock = bc.GetBlock(newBlock.ParentHash()); newBlock == nil {
+			return nil, nil, ErrMissingParentBlock
+		}
+	}
+	for !oldBlock.Hash().Equals(newBlock.Hash()) {
+		reverted = append(reverted, oldBlock)
+		attached = append(attached, newBlock)
+		if oldBlock = bc.GetBlock(oldBlock.ParentHash()); oldBlock == nil {
+			return nil, nil, ErrMissingParentBlock
+		}
+		if newBlock = bc.GetBlock(newBlock.ParentHash()); newBlock == nil {
+			return nil, nil, ErrMissingParentBlock
+		}
+	}
+
+	for i, j := 0, len(attached)-1; i < j; i, j = i+1, j-1 {
+		attached[i], attached[j] = attached[j], attached[i]
+	}
+	return reverted, attached, nil
+}
+
 // GetBlockOnCanonicalChainByHash check if a block is on canonical chain
 func (bc *BlockChain) GetBlockOnCanonicalChainByHash(blockHash byteutils.Hash) *Block {
 	blockByHash := bc.GetBlock(blockHash)
@@ -276,13 +482,30 @@ func (bc *BlockChain) GetBlockOnCanonicalChainByHeight(height uint64) *Block {
 		return nil
 	}
 
-	blockHash, err := bc.db.Get(byteutils.FromUint64(height))
+	blockHash, err := bc.heights.Get(byteutils.FromUint64(height))
 	if err != nil {
 		return nil
 	}
 	return bc.GetBlock(blockHash)
 }
 
+// GetTransaction return the tx with the given hash on canonical chain and the block which contains it.
+func (bc *BlockChain) GetTransaction(hash byteutils.Hash) (*Transaction, *Block) {
+	blockHash, err := bc.txIndices.Get(hash)
+	if err != nil {
+		return nil, nil
+	}
+	block := bc.GetBlockOnCanonicalChainByHash(blockHash)
+	if block == nil {
+		return nil, nil
+	}
+	tx := block.GetTransaction(hash)
+	if tx == nil {
+		return nil, nil
+	}
+	return tx, block
+}
+
 // PutVerifiedNewBlocks put verified new blocks and tails.
 func (bc *BlockChain) putVerifiedNewBlocks(parent *Block, allBlocks, tailBlocks []*Block) error {
 	for _, v := range allBlocks {
@@ -323,7 +546,7 @@ func (bc *BlockChain) StoreBlockToStorage(block *Block) error {
 	if err != nil {
 		return err
 	}
-	err = bc.db.Put(block.Hash(), value)
+	err = bc.blocks.Put(block.Hash(), value)
 	if err != nil {
 		return err
 	}
@@ -339,8 +562,13 @@ func (bc *BlockChain) FixedBlock() *Block         { return bc.fixedBlock }
 func (bc *BlockChain) CurrentBlock() *Block       { return bc.currentBlock }
 func (bc *BlockChain) SetFixedBlock(block *Block) { bc.fixedBlock = block }
 
+// EventEmitter return the emitter of the chain events
+func (bc *BlockChain) EventEmitter() *EventEmitter {
+	return bc.eventEmitter
+}
+
 func (bc *BlockChain) LoadBlockFromStorage(blockHash byteutils.Hash) *Block {
-	value, err := bc.db.Get(blockHash)
+	value, err := bc.blocks.Get(blockHash)
 	if err != nil {
 		return nil
 	}
@@ -357,7 +585,13 @@ func (bc *BlockChain) LoadBlockFromStorage(blockHash byteutils.Hash) *Block {
 
 // StartActiveSync start active sync task
 func (bc *BlockChain) StartActiveSync() bool {
+	if bc.sync == nil {
+		return false
+	}
 	if bc.sync.StartActiveSync() {
+		if bc.consensus == nil {
+			return true
+		}
 		bc.consensus.SuspendMining()
 		go func() {
 			bc.sync.WaitingForFinish()
@@ -370,6 +604,9 @@ func (bc *BlockChain) StartActiveSync() bool {
 
 // IsActiveSyncing returns true if being syncing
 func (bc *BlockChain) IsActiveSyncing() bool {
+	if bc.sync == nil {
+		return false
+	}
 	return bc.sync.IsActiveSyncing()
 }
 
@@ -393,7 +630,9 @@ func (bc *BlockChain) loop() {
 			logging.CLog().Info("Stopped BlockChain.")
 			return
 		case <-timerChan:
-			bc.Consensus().UpdateFixedBlock()
+			if bc.consensus != nil {
+				bc.consensus.UpdateFixedBlock()
+			}
 		}
 	}
 }
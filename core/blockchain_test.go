@@ -0,0 +1,69 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestSetHeadAndReattach(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sethead")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	chain := newTestChain(t, testConfig(t, dir))
+	var blocks []*Block
+	for i := 0; i < 5; i++ {
+		block := newTestBlock(t, chain.TailBlock())
+		if err := chain.BlockPool().Push(block); err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	reverted, err := chain.SetHead(2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reverted) != 3 || chain.TailBlock().Height() != 2 {
+		t.Fatalf("reverted %d blocks to height %d, want 3 to height 2", len(reverted), chain.TailBlock().Height())
+	}
+	for _, block := range blocks[2:] {
+		if chain.GetBlock(block.Hash()) != nil {
+			t.Fatalf("discarded block %d still stored", block.Height())
+		}
+		if chain.GetBlockOnCanonicalChainByHeight(block.Height()) != nil {
+			t.Fatalf("discarded block %d still indexed", block.Height())
+		}
+	}
+
+	// the discarded blocks are received again and attached.
+	for _, block := range blocks[2:] {
+		if err := chain.BlockPool().Push(block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !chain.TailBlock().Hash().Equals(blocks[4].Hash()) {
+		t.Fatalf("tail %d after reattach, want 5", chain.TailBlock().Height())
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package core
+
+import (
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"errors"
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrStaleHeightIndex = errors.New("height index does not point to the block")
+)
+
+// chainBatch the writes of a commit to the tables of the chain, applied
+// atomically by write whatever the batch mode of the storage.
+type chainBatch struct {
+	batch cdb.WriteBatch
+
+	blocks    cdb.WriteBatch
+	meta      cdb.WriteBatch
+	heights   cdb.WriteBatch
+	txIndices cdb.WriteBatch
+	tries     cdb.WriteBatch
+
+	tables *chainTables
+	cached []*cachedBatch
+}
+
+// cachedBatch record the keys written to a cached table in a chain batch, to
+// invalidate them once the batch is written.
+type cachedBatch struct {
+	cdb.WriteBatch
+	cache *cdb.CachedStorage
+	keys  [][]byte
+}
+
+func (b *cachedBatch) Put(key, value []byte) error {
+	b.keys = append(b.keys, append([]byte{}, key...))
+	return b.WriteBatch.Put(key, value)
+}
+
+func (b *cachedBatch) Delete(key []byte) error {
+	b.keys = append(b.keys, append([]byte{}, key...))
+	return b.WriteBatch.Delete(key)
+}
+
+// newBatch returns an empty batch of the tables.
+func (t *chainTables) newBatch() *chainBatch {
+	cb := &chainBatch{
+		batch:  t.shared.NewWriteBatch(),
+		tables: t,
+	}
+	cb.blocks = cb.table(t.blocks, BlockTable)
+	cb.meta = cb.table(t.meta, MetaTable)
+	cb.heights = cb.table(t.heights, HeightTable)
+	cb.txIndices = cb.table(t.txIndices, TxIndexTable)
+	cb.tries = cb.table(t.tries, TrieTable)
+	return cb
+}
+
+func (cb *chainBatch) table(table cdb.Storage, prefix string) cdb.WriteBatch {
+	batch := cdb.NewTable(cb.tables.shared, prefix).Batch(cb.batch)
+	if cache, ok := table.(*cdb.CachedStorage); ok {
+		cached := &cachedBatch{WriteBatch: batch, cache: cache}
+		cb.cached = append(cb.cached, cached)
+		return cached
+	}
+	return batch
+}
+
+func (cb *chainBatch) putBlock(block *Block) error {
+	pbBlock, err := block.ToProto()
+	if err != nil {
+		return err
+	}
+	value, err := proto.Marshal(pbBlock)
+	if err != nil {
+		return err
+	}
+	return cb.blocks.Put(block.Hash(), value)
+}
+
+func (cb *chainBatch) setTail(block *Block) error {
+	return cb.meta.Put([]byte(Tail), block.Hash())
+}
+
+func (cb *chainBatch) setFixed(block *Block) error {
+	return cb.meta.Put([]byte(FIXED), block.Hash())
+}
+
+// buildIndices build the height and tx indices of a block on canonical chain.
+func (cb *chainBatch) buildIndices(block *Block) error {
+	if err := cb.heights.Put(byteutils.FromUint64(block.Height()), block.Hash()); err != nil {
+		return err
+	}
+	for _, tx := range block.Transactions() {
+		if err := cb.txIndices.Put(tx.Hash(), block.Hash()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropIndices drop the height and tx indices of a block detached from canonical chain.
+func (cb *chainBatch) dropIndices(block *Block) error {
+	heightKey := byteutils.FromUint64(block.Height())
+	if hash, err := cb.tables.heights.Get(heightKey); err == nil && block.Hash().Equals(hash) {
+		if err := cb.heights.Delete(heightKey); err != nil {
+			return err
+		}
+	}
+	for _, tx := range block.Transactions() {
+		if err := cb.txIndices.Delete(tx.Hash()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dropBlock delete the body of a block discarded from the chain.
+func (cb *chainBatch) dropBlock(block *Block) error {
+	return cb.blocks.Delete(block.Hash())
+}
+
+func (cb *chainBatch) write() error {
+	if err := cb.batch.Write(); err != nil {
+		return err
+	}
+	for _, cached := range cb.cached {
+		cached.cache.Invalidate(cached.keys...)
+	}
+	return nil
+}
+
+// trieBatch spread the trie nodes of a commit over chain batches of at most
+// cdb.IdealBatchLen writes and cdb.IdealBatchSize bytes, so that a large
+// commit fits the bound of the storage. The nodes are keyed by their hash, so
+// the batches filled first can be written ahead of the last one, which
+// completes the commit.
+type trieBatch struct {
+	tables  *chainTables
+	batches []*chainBatch
+	size    int
+}
+
+func newTrieBatch(tables *chainTables) *trieBatch {
+	tb := &trieBatch{tables: tables}
+	tb.Reset()
+	return tb
+}
+
+// last returns the chain batch written last.
+func (tb *trieBatch) last() *chainBatch {
+	return tb.batches[len(tb.batches)-1]
+}
+
+// grow start a new chain batch if the last one can't take size more bytes.
+func (tb *trieBatch) grow(size int) {
+	count := tb.last().batch.Len()
+	if count > 0 && (count >= cdb.IdealBatchLen || tb.size+size > cdb.IdealBatchSize) {
+		tb.batches = append(tb.batches, tb.tables.newBatch())
+		tb.size = 0
+	}
+	tb.size += size
+}
+
+func (tb *trieBatch) Put(key, value []byte) error {
+	tb.grow(len(key) + len(value))
+	return tb.last().tries.Put(key, value)
+}
+
+func (tb *trieBatch) Delete(key []byte) error {
+	tb.grow(len(key))
+	return tb.last().tries.Delete(key)
+}
+
+func (tb *trieBatch) Len() int {
+	count := 0
+	for _, cb := range tb.batches {
+		count += cb.batch.Len()
+	}
+	return count
+}
+
+// Write write the chain batches in order.
+func (tb *trieBatch) Write() error {
+	for _, cb := range tb.batches {
+		if err := cb.write(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tb *trieBatch) Reset() {
+	tb.batches = []*chainBatch{tb.tables.newBatch()}
+	tb.size = 0
+}
+
+// recoverTail returns the stored tail if it's fully committed, with its body,
+// state and txs tries and height index. Otherwise the last commit was
+// interrupted, and the tail is rolled back to the highest fully committed
+// block, moving the fixed pointer back too if needed.
+func (bc *BlockChain) recoverTail(tail byteutils.Hash) (*Block, error) {
+	var (
+		hash     = tail
+		height   uint64
+		known    bool
+		reverted []*Block
+	)
+	for {
+		block, err := LoadBlockFromStorage(hash, bc)
+		if err == nil && known && block.Height() != height {
+			err = ErrBrokenBlockHeight
+		}
+		if err == nil {
+			indexed, _ := bc.heights.Get(byteutils.FromUint64(block.Height()))
+			if block.Hash().Equals(indexed) {
+				if !block.Hash().Equals(tail) {
+					return block, bc.rollbackTail(block, reverted)
+				}
+				return block, nil
+			}
+			err = ErrStaleHeightIndex
+		}
+		logging.CLog().WithFields(logrus.Fields{
+			"hash": hash,
+			"err":  err,
+		}).Warn("Found a partially committed tail block.")
+
+		if stored, err := loadStoredBlock(bc.chainTables, hash); err == nil && stored.Height() > 0 {
+			reverted = append(reverted, stored)
+			hash, height, known = stored.ParentHash(), stored.Height()-1, true
+			continue
+		}
+		if !known {
+			// the height of the tail is unknown, restart from the highest indexed block.
+			if height, hash = highestIndexedBlock(bc.chainTables); hash == nil {
+				return nil, ErrNoConsistentBlock
+			}
+			known = true
+			continue
+		}
+		if height == 0 {
+			return nil, ErrNoConsistentBlock
+		}
+		height--
+		if hash, err = bc.heights.Get(byteutils.FromUint64(height)); err != nil {
+			return nil, ErrNoConsistentBlock
+		}
+	}
+}
+
+// rollbackTail reset the tail to block in one batch, dropping the indices of
+// the reverted blocks and moving the fixed pointer back if it's above block.
+func (bc *BlockChain) rollbackTail(block *Block, reverted []*Block) error {
+	batch := bc.newBatch()
+	for _, v := range reverted {
+		if err := batch.dropIndices(v); err != nil {
+			return err
+		}
+	}
+	if err := batch.setTail(block); err != nil {
+		return err
+	}
+	fixedHash, err := bc.meta.Get([]byte(FIXED))
+	if err != nil && err != cdb.ErrKeyNotFound {
+		return err
+	}
+	if fixedHash != nil {
+		if fixed, err := loadStoredBlock(bc.chainTables, fixedHash); err != nil || fixed.Height() > block.Height() {
+			if err := batch.setFixed(block); err != nil {
+				return err
+			}
+		}
+	}
+	if err := batch.write(); err != nil {
+		return err
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"tail":     block,
+		"reverted": len(reverted),
+	}).Warn("Rolled back a partial commit.")
+	return nil
+}
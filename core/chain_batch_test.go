@@ -0,0 +1,68 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package core
+
+import (
+	"gamc.pro/gamcio/go-gamc/crypto/hash"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestTrieBatchSplit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "triebatch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	chain := newTestChain(t, testConfig(t, dir))
+	block := newTestBlock(t, chain.TailBlock())
+
+	batch := newTrieBatch(chain.chainTables)
+	n := 3 * cdb.IdealBatchLen
+	var keys [][]byte
+	for i := 0; i < n; i++ {
+		value := byteutils.FromUint64(uint64(i))
+		key := hash.Sha3256(value)
+		if err := batch.Put(key, value); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	if err := batch.last().putBlock(block); err != nil {
+		t.Fatal(err)
+	}
+	if len(batch.batches) != 3 || batch.Len() != n+1 {
+		t.Fatalf("%d writes in %d batches, want %d in 3", batch.Len(), len(batch.batches), n+1)
+	}
+
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if has, _ := chain.tries.Has(key); !has {
+			t.Fatalf("trie node %x not written", key)
+		}
+	}
+	if _, err := chain.blocks.Get(block.Hash()); err != nil {
+		t.Fatalf("block of the last batch not written: %v", err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"gamc.pro/gamcio/go-gamc/util/config"
 	"errors"
 	"math/big"
+	"time"
 )
 
 var (
@@ -64,6 +65,12 @@ type Synchronize interface {
 type AccountManager interface {
 	NewAccount(passphrase []byte) (*Address, string, error)
 	UpdateAccount(address string, oldPassphrase, newPassphrase []byte) error
+	ImportAccount(priKey, passphrase []byte) (*Address, error)
+	Import(keyjson, passphrase []byte) (*Address, error)
+	Export(address *Address, passphrase []byte) ([]byte, error)
+	GetAllAddress() []*Address
+	UnLock(address *Address, passphrase []byte, duration time.Duration) error
+	Lock(address *Address) error
 	Sign(address *Address, hash []byte) ([]byte, error)
 	SignBlock(address *Address, block *Block) error
 	Verify(pubKey []byte, message, sig []byte) bool
@@ -0,0 +1,139 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package core
+
+import (
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+// Event topics
+const (
+	// TopicNewTailBlock the topic of the blocks attached to the canonical chain
+	TopicNewTailBlock = "chain.newTailBlock"
+	// TopicRevertBlock the topic of the blocks detached from the canonical chain by a reorg
+	TopicRevertBlock = "chain.revertBlock"
+	// TopicPendingTransaction the topic of the transactions entering the tx pool
+	TopicPendingTransaction = "chain.pendingTransaction"
+)
+
+// Event event of the chain
+type Event struct {
+	Topic       string
+	Block       *Block
+	Transaction *Transaction
+}
+
+// EventSubscriber subscriber of the chain events
+type EventSubscriber struct {
+	eventCh chan *Event
+	topics  []string
+}
+
+// NewEventSubscriber return new EventSubscriber instance, size is the buffer size of the events.
+func NewEventSubscriber(size int, topics []string) *EventSubscriber {
+	return &EventSubscriber{
+		eventCh: make(chan *Event, size),
+		topics:  topics,
+	}
+}
+
+// EventChan return the event chan, it is closed when the subscriber is evicted for falling behind.
+func (s *EventSubscriber) EventChan() chan *Event {
+	return s.eventCh
+}
+
+// Topics return the subscribed topics
+func (s *EventSubscriber) Topics() []string {
+	return s.topics
+}
+
+// EventEmitter dispatches the chain events to the subscribers.
+// The dispatch never blocks the emitter: a subscriber whose buffer is full
+// is evicted and its event chan is closed.
+type EventEmitter struct {
+	mu          sync.Mutex
+	subscribers map[string]map[*EventSubscriber]bool
+}
+
+// NewEventEmitter return new EventEmitter instance.
+func NewEventEmitter() *EventEmitter {
+	return &EventEmitter{
+		subscribers: make(map[string]map[*EventSubscriber]bool),
+	}
+}
+
+// Register register the subscribers.
+func (emitter *EventEmitter) Register(subscribers ...*EventSubscriber) {
+	emitter.mu.Lock()
+	defer emitter.mu.Unlock()
+
+	for _, sub := range subscribers {
+		for _, topic := range sub.topics {
+			subs, ok := emitter.subscribers[topic]
+			if !ok {
+				subs = make(map[*EventSubscriber]bool)
+				emitter.subscribers[topic] = subs
+			}
+			subs[sub] = true
+		}
+	}
+}
+
+// Deregister deregister the subscribers.
+func (emitter *EventEmitter) Deregister(subscribers ...*EventSubscriber) {
+	emitter.mu.Lock()
+	defer emitter.mu.Unlock()
+
+	for _, sub := range subscribers {
+		emitter.remove(sub)
+	}
+}
+
+// Trigger dispatch the event to the subscribers of its topic.
+func (emitter *EventEmitter) Trigger(e *Event) {
+	emitter.mu.Lock()
+	defer emitter.mu.Unlock()
+
+	for sub := range emitter.subscribers[e.Topic] {
+		select {
+		case sub.eventCh <- e:
+		default:
+			logging.VLog().WithFields(logrus.Fields{
+				"topic":  e.Topic,
+				"topics": sub.topics,
+			}).Debug("Evicted a subscriber which is falling behind.")
+			if emitter.remove(sub) {
+				close(sub.eventCh)
+			}
+		}
+	}
+}
+
+// remove the subscriber from all its topics, return false if it was not registered.
+func (emitter *EventEmitter) remove(sub *EventSubscriber) bool {
+	found := false
+	for _, topic := range sub.topics {
+		if subs, ok := emitter.subscribers[topic]; ok && subs[sub] {
+			delete(subs, sub)
+			found = true
+		}
+	}
+	return found
+}
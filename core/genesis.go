@@ -99,7 +99,7 @@ func NewGenesis(cfg *config.Config, chain *BlockChain) (*Block, error) {
 		term:      0,
 		timestamp: GenesisTimestamp,
 	}
-	worldState, err := NewWorldState(chain.db)
+	worldState, err := newWorldState(chain.chainTables)
 	coinbase, err := AddressParse(genesisConf.Coinbase)
 
 	header := &BlockHeader{
@@ -115,7 +115,7 @@ func NewGenesis(cfg *config.Config, chain *BlockChain) (*Block, error) {
 	}
 	genesis.worldState = worldState
 	genesis.header = header
-	genesis.db = chain.db
+	genesis.tables = chain.chainTables
 
 	if err := genesis.Begin(); err != nil {
 		return nil, err
@@ -176,8 +176,12 @@ func NewGenesis(cfg *config.Config, chain *BlockChain) (*Block, error) {
 
 	genesis.header.hash = genesis.CalcHash()
 
-	genesis.header.stateRoot = genesis.WorldState().AccountsRoot()
-	genesis.header.txsRoot = genesis.WorldState().TxsRoot()
+	if genesis.header.stateRoot, err = genesis.WorldState().AccountsRoot(); err != nil {
+		return nil, err
+	}
+	if genesis.header.txsRoot, err = genesis.WorldState().TxsRoot(); err != nil {
+		return nil, err
+	}
 	return &genesis, nil
 }
 
@@ -0,0 +1,331 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package core
+
+import (
+	corepb "gamc.pro/gamcio/go-gamc/core/pb"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/trie"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"errors"
+	"fmt"
+	"github.com/gogo/protobuf/proto"
+)
+
+var (
+	ErrBrokenBlockHeight   = errors.New("block height does not follow its child")
+	ErrBrokenGenesisBlock  = errors.New("chain does not end at the genesis block")
+	ErrMissingTailPointer  = errors.New("tail pointer is missing")
+	ErrMissingFixedPointer = errors.New("fixed pointer is missing")
+	ErrBrokenFixedPointer  = errors.New("fixed block is not on the verified chain")
+	ErrNoConsistentBlock   = errors.New("no fully consistent block to reset the tail to")
+)
+
+// IntegrityProblem a problem found in the chain data.
+type IntegrityProblem struct {
+	Height uint64
+	Hash   byteutils.Hash
+	Err    error
+}
+
+func (p *IntegrityProblem) String() string {
+	return fmt.Sprintf("height %d, hash %s: %v", p.Height, p.Hash, p.Err)
+}
+
+// IntegrityReport the result of VerifyChainIntegrity.
+type IntegrityReport struct {
+	Tail  byteutils.Hash
+	Fixed byteutils.Hash
+
+	// Checked the number of blocks checked.
+	Checked int
+	// TrieNodes the number of distinct trie nodes checked.
+	TrieNodes int
+	// LastGood the highest block whose ancestors up to genesis are all consistent.
+	LastGood *Block
+	// FixedOK is true if the fixed block is an ancestor of LastGood.
+	FixedOK bool
+
+	Problems []*IntegrityProblem
+	// StaleIndices the canonical hashes of the heights whose index is missing or wrong.
+	StaleIndices map[uint64]byteutils.Hash
+}
+
+// Clean returns true if no problem is found.
+func (r *IntegrityReport) Clean() bool {
+	return len(r.Problems) == 0 && len(r.StaleIndices) == 0
+}
+
+func (r *IntegrityReport) addProblem(height uint64, hash byteutils.Hash, err error) {
+	r.Problems = append(r.Problems, &IntegrityProblem{Height: height, Hash: hash, Err: err})
+}
+
+// VerifyChainIntegrity walk from the tail block back to genesis, checking the hash,
+// parent link and height of each stored block, the presence of its state and txs
+// tries, and the consistency of the tail and fixed pointers. It only reads the
+// storage, so it works even if the chain can't be loaded. progress is called
+// after each consistent block.
+func VerifyChainIntegrity(db cdb.Storage, progress func(block *Block)) (*IntegrityReport, error) {
+	report := &IntegrityReport{StaleIndices: make(map[uint64]byteutils.Hash)}
+	tables := newChainTables(db)
+
+	var err error
+	report.Tail, err = tables.meta.Get([]byte(Tail))
+	if err != nil && err != cdb.ErrKeyNotFound {
+		return nil, err
+	}
+	report.Fixed, err = tables.meta.Get([]byte(FIXED))
+	if err != nil && err != cdb.ErrKeyNotFound {
+		return nil, err
+	}
+	if report.Fixed == nil {
+		report.addProblem(0, nil, ErrMissingFixedPointer)
+	}
+
+	var (
+		hash    = report.Tail
+		height  uint64
+		known   bool
+		genesis bool
+		fixed   *Block
+		seen    = make(map[string]bool)
+	)
+	if hash == nil {
+		report.addProblem(0, nil, ErrMissingTailPointer)
+		if height, hash = highestIndexedBlock(tables); hash != nil {
+			known = true
+		}
+	}
+
+	for hash != nil {
+		block, err := loadStoredBlock(tables, hash)
+		if err == nil && known && block.Height() != height {
+			err = ErrBrokenBlockHeight
+		}
+		if err == nil {
+			err = checkBlockTries(tables, block, seen, report)
+		}
+		if err != nil {
+			report.addProblem(height, hash, err)
+			report.LastGood = nil
+			if !known {
+				// the height of a broken tail is unknown, restart from the highest indexed block.
+				if height, hash = highestIndexedBlock(tables); hash == nil {
+					break
+				}
+				known = true
+				continue
+			}
+			// resume from the canonical index below the broken block.
+			if height == 0 {
+				break
+			}
+			height--
+			if hash, err = tables.heights.Get(byteutils.FromUint64(height)); err != nil {
+				report.addProblem(height, nil, err)
+				break
+			}
+			continue
+		}
+
+		report.Checked++
+		if report.LastGood == nil {
+			report.LastGood = block
+		}
+		if indexed, err := tables.heights.Get(byteutils.FromUint64(block.Height())); err != nil || !block.Hash().Equals(indexed) {
+			report.StaleIndices[block.Height()] = block.Hash()
+		}
+		if block.Hash().Equals(report.Fixed) {
+			fixed = block
+		}
+		if progress != nil {
+			progress(block)
+		}
+
+		if block.Height() == 0 {
+			genesis = true
+			if !block.Hash().Equals(GenesisHash) {
+				report.addProblem(0, block.Hash(), ErrBrokenGenesisBlock)
+				report.LastGood = nil
+			}
+			break
+		}
+		hash, height, known = block.ParentHash(), block.Height()-1, true
+	}
+
+	if !genesis {
+		report.addProblem(height, hash, ErrBrokenGenesisBlock)
+		report.LastGood = nil
+	}
+	if report.LastGood != nil {
+		report.FixedOK = fixed != nil && fixed.Height() <= report.LastGood.Height()
+	}
+	if report.Fixed != nil && !report.FixedOK {
+		report.addProblem(0, report.Fixed, ErrBrokenFixedPointer)
+	}
+
+	// heights above the last good block are not canonical anymore.
+	for h := range report.StaleIndices {
+		if report.LastGood == nil || h > report.LastGood.Height() {
+			delete(report.StaleIndices, h)
+		}
+	}
+	return report, nil
+}
+
+// RepairChainIntegrity reset the tail to the last fully consistent block of the report,
+// move the fixed pointer back if it's not an ancestor of it, drop the height and tx
+// indices of the blocks above it and rebuild the stale indices, all in one batch.
+func RepairChainIntegrity(db cdb.Storage, report *IntegrityReport) error {
+	if report.LastGood == nil {
+		return ErrNoConsistentBlock
+	}
+	lastGood := report.LastGood
+	tables := newChainTables(db)
+	batch := tables.newBatch()
+
+	// the blocks above the last good one, from the tail down and by height index.
+	above := make(map[string]*Block)
+	for hash := report.Tail; hash != nil; {
+		block, err := loadStoredBlock(tables, hash)
+		if err != nil || block.Height() <= lastGood.Height() {
+			break
+		}
+		above[string(block.Hash())] = block
+		hash = block.ParentHash()
+	}
+	it := tables.heights.NewIteratorWithRange(byteutils.FromUint64(lastGood.Height()+1), nil)
+	for it.Next() {
+		block, err := loadStoredBlock(tables, it.Value())
+		if err == nil {
+			above[string(block.Hash())] = block
+			continue
+		}
+		// the block is broken, its tx indices can't be found.
+		if err := batch.heights.Delete(it.Key()); err != nil {
+			it.Release()
+			return err
+		}
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+	for _, block := range above {
+		if err := batch.dropIndices(block); err != nil {
+			return err
+		}
+	}
+
+	for _, hash := range report.StaleIndices {
+		block, err := loadStoredBlock(tables, hash)
+		if err != nil {
+			return err
+		}
+		if err := batch.buildIndices(block); err != nil {
+			return err
+		}
+	}
+	if !lastGood.Hash().Equals(report.Tail) {
+		if err := batch.setTail(lastGood); err != nil {
+			return err
+		}
+	}
+	if !report.FixedOK {
+		if err := batch.setFixed(lastGood); err != nil {
+			return err
+		}
+	}
+	if err := batch.write(); err != nil {
+		return err
+	}
+	return db.Flush()
+}
+
+// loadStoredBlock load the block without its world state, verifying its hash.
+func loadStoredBlock(tables *chainTables, hash byteutils.Hash) (*Block, error) {
+	value, err := tables.blocks.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+	pbBlock := new(corepb.Block)
+	if err := proto.Unmarshal(value, pbBlock); err != nil {
+		return nil, err
+	}
+	block := new(Block)
+	if err := block.FromProto(pbBlock); err != nil {
+		return nil, err
+	}
+	wantedHash, err := block.calcHash()
+	if err != nil {
+		return nil, err
+	}
+	if !wantedHash.Equals(hash) || !block.Hash().Equals(hash) {
+		return nil, ErrInvalidBlockHash
+	}
+	return block, nil
+}
+
+// checkBlockTries check the state trie, with the variables of every account, and the txs trie are all present.
+func checkBlockTries(tables *chainTables, block *Block, seen map[string]bool, report *IntegrityReport) error {
+	stateTrie, err := trie.NewTrie(block.StateRoot(), tables.tries, false)
+	if err != nil {
+		return err
+	}
+	nodes, err := stateTrie.CheckIntegrity(seen, func(value []byte) error {
+		pbAcc := new(corepb.Account)
+		if err := proto.Unmarshal(value, pbAcc); err != nil {
+			return err
+		}
+		varsTrie, err := trie.NewTrie(pbAcc.VarsHash, tables.tries, false)
+		if err != nil {
+			return err
+		}
+		n, err := varsTrie.CheckIntegrity(seen, nil)
+		report.TrieNodes += n
+		return err
+	})
+	report.TrieNodes += nodes
+	if err != nil {
+		return err
+	}
+
+	txsTrie, err := trie.NewTrie(block.TxsRoot(), tables.tries, false)
+	if err != nil {
+		return err
+	}
+	nodes, err = txsTrie.CheckIntegrity(seen, nil)
+	report.TrieNodes += nodes
+	return err
+}
+
+// highestIndexedBlock returns the highest height with a canonical index.
+func highestIndexedBlock(tables *chainTables) (uint64, byteutils.Hash) {
+	var (
+		height uint64
+		hash   byteutils.Hash
+	)
+	for h := uint64(0); ; h++ {
+		indexed, err := tables.heights.Get(byteutils.FromUint64(h))
+		if err != nil {
+			break
+		}
+		height, hash = h, indexed
+	}
+	return height, hash
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+)
+
+func TestRepairChainIntegrity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "integrity")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	chain := newTestChain(t, testConfig(t, dir))
+	var blocks []*Block
+	for i := 0; i < 5; i++ {
+		block := newTestBlock(t, chain.TailBlock())
+		if err := chain.BlockPool().Push(block); err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	// lose the body of the block at height 4.
+	if err := cdb.NewTable(chain.db, BlockTable).Delete(blocks[3].Hash()); err != nil {
+		t.Fatal(err)
+	}
+	report, err := VerifyChainIntegrity(chain.db, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Clean() || report.LastGood == nil || report.LastGood.Height() != 3 {
+		t.Fatalf("report of broken chain: clean %v, last good %v", report.Clean(), report.LastGood)
+	}
+
+	if err := RepairChainIntegrity(chain.db, report); err != nil {
+		t.Fatal(err)
+	}
+	heights := cdb.NewTable(chain.db, HeightTable)
+	for height := uint64(4); height <= 5; height++ {
+		if _, err := heights.Get(byteutils.FromUint64(height)); err != cdb.ErrKeyNotFound {
+			t.Fatalf("height %d still indexed after repair: %v", height, err)
+		}
+	}
+	tail, err := cdb.NewTable(chain.db, MetaTable).Get([]byte(Tail))
+	if err != nil || !blocks[2].Hash().Equals(tail) {
+		t.Fatalf("tail %x after repair, want %x", tail, blocks[2].Hash())
+	}
+
+	report, err = VerifyChainIntegrity(chain.db, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Clean() {
+		t.Fatalf("problems after repair: %v", report.Problems)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package core
+
+import (
+	"math/big"
+)
+
+// NoConsensus is the Consensus used when the chain runs without a consensus engine.
+// It never mines and skips the consensus check of the blocks, so the blocks are
+// only verified by their integrity and by executing their transactions.
+type NoConsensus struct{}
+
+// NewNoConsensus return a Consensus skipping the consensus check.
+func NewNoConsensus() *NoConsensus {
+	return &NoConsensus{}
+}
+
+func (c *NoConsensus) Setup(gamc gamc) {}
+func (c *NoConsensus) Start()          {}
+func (c *NoConsensus) Stop()           {}
+
+func (c *NoConsensus) EnableMining()  {}
+func (c *NoConsensus) DisableMining() {}
+func (c *NoConsensus) IsEnable() bool { return false }
+
+func (c *NoConsensus) ResumeMining()   {}
+func (c *NoConsensus) SuspendMining()  {}
+func (c *NoConsensus) IsSuspend() bool { return false }
+
+func (c *NoConsensus) UpdateFixedBlock() {}
+
+// VerifyBlock accepts all the blocks.
+func (c *NoConsensus) VerifyBlock(block *Block) error { return nil }
+
+// AccumulateRewards does nothing, no rewards are paid without consensus.
+func (c *NoConsensus) AccumulateRewards(addr Address, reward *big.Int) error { return nil }
@@ -50,6 +50,8 @@ var (
 	ErrInvalidBlockHash          = errors.New("invalid block hash")
 
 	ErrInvalidTransactionSigner = errors.New("invalid transaction signer")
+	ErrMissingTransactionSign   = errors.New("transaction is not signed")
+	ErrInvalidTransactionSign   = errors.New("invalid transaction signature")
 	ErrInvalidPublicKey         = errors.New("invalid public key")
 
 	ErrMissingParentBlock                                = errors.New("cannot find the block's parent block in storage")
@@ -60,4 +62,7 @@ var (
 
 	ErrInvalidBlockStateRoot = errors.New("invalid block state root hash")
 	ErrInvalidBlockTxsRoot   = errors.New("invalid block txs root hash")
+
+	ErrInvalidSetHeadHeight = errors.New("set head height is above the tail block")
+	ErrSetHeadBelowFixed    = errors.New("set head height is below the fixed block")
 )
@@ -0,0 +1,287 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package core
+
+import (
+	corepb "gamc.pro/gamcio/go-gamc/core/pb"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/trie"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"gamc.pro/gamcio/go-gamc/util/config"
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"errors"
+	"github.com/gogo/protobuf/proto"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Reindex Key in storage of the last checkpointed block of an unfinished reindex
+	Reindex = "blockchain_reindex"
+	// ReindexCheckpointInterval is the number of blocks replayed between checkpoints
+	ReindexCheckpointInterval = 1024
+)
+
+var (
+	ErrReindexTargetNotEmpty   = errors.New("reindex target already holds a chain")
+	ErrReindexSourceChanged    = errors.New("reindex checkpoint is not on the source chain")
+	ErrReindexGenesisMismatch  = errors.New("reindex target genesis does not match the source")
+	ErrInvalidReindexSnapshot  = errors.New("snapshot height is above the source tail")
+	ErrReindexSnapshotConflict = errors.New("can't change the snapshot of an unfinished reindex")
+)
+
+// ReindexChain replay the canonical blocks of source into the fresh storage target,
+// re-executing every block on top of its parent state, verifying its stateRoot and
+// txsRoot, and rebuilding the height and tx indices. If snapshot is not zero, the
+// state of the block at that height is copied from source instead of being replayed,
+// the blocks below it are copied with their indices but without their states.
+//
+// Progress is checkpointed in target every ReindexCheckpointInterval blocks, calling
+// it again on the same target resumes from the last checkpoint. It returns the new
+// tail block of target.
+func ReindexChain(config *config.Config, source, target cdb.Storage, snapshot uint64, progress func(block *Block)) (*Block, error) {
+	if err := InitSchema(source); err != nil {
+		return nil, err
+	}
+	if err := InitSchema(target); err != nil {
+		return nil, err
+	}
+
+	src := newChainTables(source)
+	hashes, err := canonicalHashes(src)
+	if err != nil {
+		return nil, err
+	}
+	tailHeight := uint64(len(hashes) - 1)
+	if snapshot > tailHeight {
+		return nil, ErrInvalidReindexSnapshot
+	}
+
+	chain, err := NewBlockChain(config, nil, target)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint, err := chain.meta.Get([]byte(Reindex))
+	if err != nil && err != cdb.ErrKeyNotFound {
+		return nil, err
+	}
+	tail, err := chain.meta.Get([]byte(Tail))
+	if err != nil && err != cdb.ErrKeyNotFound {
+		return nil, err
+	}
+
+	var parent *Block
+	switch {
+	case checkpoint != nil:
+		if parent, err = LoadBlockFromStorage(checkpoint, chain); err != nil {
+			return nil, err
+		}
+		if parent.Height() > tailHeight || !hashes[parent.Height()].Equals(parent.Hash()) {
+			return nil, ErrReindexSourceChanged
+		}
+		if snapshot > parent.Height() {
+			return nil, ErrReindexSnapshotConflict
+		}
+		logging.CLog().WithFields(logrus.Fields{
+			"checkpoint": parent,
+		}).Info("Resuming reindex.")
+	case tail != nil:
+		return nil, ErrReindexTargetNotEmpty
+	default:
+		if parent, err = chain.LoadGenesisFromStorage(); err != nil {
+			return nil, err
+		}
+		if !parent.Hash().Equals(hashes[0]) {
+			return nil, ErrReindexGenesisMismatch
+		}
+		if snapshot > 0 {
+			if parent, err = copyReindexSnapshot(chain, src, hashes[:snapshot+1], progress); err != nil {
+				return nil, err
+			}
+		}
+		if err := reindexCheckpoint(chain, parent); err != nil {
+			return nil, err
+		}
+	}
+
+	for height := parent.Height() + 1; height <= tailHeight; height++ {
+		block, err := loadStoredBlock(src, hashes[height])
+		if err != nil {
+			return nil, err
+		}
+		if err := block.LinkParentBlock(chain, parent); err != nil {
+			return nil, err
+		}
+		if err := block.VerifyExecution(); err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"block": block,
+				"err":   err,
+			}).Error("Failed to replay block.")
+			return nil, err
+		}
+		if err := storeReindexedBlock(chain, block); err != nil {
+			return nil, err
+		}
+		parent = block
+
+		if height%ReindexCheckpointInterval == 0 {
+			if err := reindexCheckpoint(chain, parent); err != nil {
+				return nil, err
+			}
+		}
+		if progress != nil {
+			progress(block)
+		}
+	}
+
+	if err := chain.StoreTailHashToStorage(parent); err != nil {
+		return nil, err
+	}
+	fixed := parent
+	if hash, err := src.meta.Get([]byte(FIXED)); err == nil {
+		if block, err := loadStoredBlock(src, hash); err == nil &&
+			block.Height() <= parent.Height() && hashes[block.Height()].Equals(hash) {
+			fixed = block
+		}
+	}
+	if err := chain.StoreFIXEDHashToStorage(fixed); err != nil {
+		return nil, err
+	}
+	if err := chain.meta.Delete([]byte(Reindex)); err != nil {
+		return nil, err
+	}
+	if err := target.Flush(); err != nil {
+		return nil, err
+	}
+	return parent, nil
+}
+
+// storeReindexedBlock store the block with its height and tx indices in one batch.
+func storeReindexedBlock(chain *BlockChain, block *Block) error {
+	batch := chain.newBatch()
+	if err := batch.putBlock(block); err != nil {
+		return err
+	}
+	if err := batch.buildIndices(block); err != nil {
+		return err
+	}
+	return batch.write()
+}
+
+// reindexCheckpoint persist the progress of the reindex up to block.
+func reindexCheckpoint(chain *BlockChain, block *Block) error {
+	if err := chain.StoreTailHashToStorage(block); err != nil {
+		return err
+	}
+	if err := chain.meta.Put([]byte(Reindex), block.Hash()); err != nil {
+		return err
+	}
+	return chain.db.Flush()
+}
+
+// copyReindexSnapshot copy the blocks of hashes above genesis into chain, with the txs
+// trie of every block and the state trie of the last one, which is returned.
+func copyReindexSnapshot(chain *BlockChain, src *chainTables, hashes []byteutils.Hash, progress func(block *Block)) (*Block, error) {
+	seen := make(map[string]bool)
+	for height := 1; height < len(hashes); height++ {
+		block, err := loadStoredBlock(src, hashes[height])
+		if err != nil {
+			return nil, err
+		}
+		txsTrie, err := trie.NewTrie(block.TxsRoot(), src.tries, false)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := txsTrie.CopyNodes(chain.tries, seen, nil); err != nil {
+			return nil, err
+		}
+		if err := storeReindexedBlock(chain, block); err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(block)
+		}
+	}
+
+	last := hashes[len(hashes)-1]
+	block, err := loadStoredBlock(src, last)
+	if err != nil {
+		return nil, err
+	}
+	stateTrie, err := trie.NewTrie(block.StateRoot(), src.tries, false)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := stateTrie.CopyNodes(chain.tries, seen, func(value []byte) error {
+		pbAcc := new(corepb.Account)
+		if err := proto.Unmarshal(value, pbAcc); err != nil {
+			return err
+		}
+		varsTrie, err := trie.NewTrie(pbAcc.VarsHash, src.tries, false)
+		if err != nil {
+			return err
+		}
+		_, err = varsTrie.CopyNodes(chain.tries, seen, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	logging.CLog().WithFields(logrus.Fields{
+		"snapshot": block,
+		"nodes":    nodes,
+	}).Info("Copied snapshot state.")
+
+	return LoadBlockFromStorage(last, chain)
+}
+
+// canonicalHashes returns the hashes of the canonical chain indexed by height,
+// walking from the tail block back to genesis.
+func canonicalHashes(tables *chainTables) ([]byteutils.Hash, error) {
+	hash, err := tables.meta.Get([]byte(Tail))
+	if err == cdb.ErrKeyNotFound {
+		return nil, ErrMissingTailPointer
+	}
+	if err != nil {
+		return nil, err
+	}
+	var (
+		hashes []byteutils.Hash
+		height uint64
+	)
+	for {
+		block, err := loadStoredBlock(tables, hash)
+		if err != nil {
+			return nil, err
+		}
+		if hashes == nil {
+			height = block.Height()
+			hashes = make([]byteutils.Hash, height+1)
+		} else if block.Height() != height {
+			return nil, ErrBrokenBlockHeight
+		}
+		hashes[height] = block.Hash()
+		if height == 0 {
+			if !block.Hash().Equals(GenesisHash) {
+				return nil, ErrBrokenGenesisBlock
+			}
+			return hashes, nil
+		}
+		hash, height = block.ParentHash(), height-1
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package core
+
+import (
+	"gamc.pro/gamcio/go-gamc/crypto/hash"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"bytes"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"strings"
+)
+
+const (
+	// SchemaVersion is the version of the storage layout written by this code
+	SchemaVersion uint32 = 1
+	// SchemaVersionKey Key in MetaTable of the storage layout version
+	SchemaVersionKey = "schema_version"
+	// MigrationBatchSize is the number of keys migrated between flushes
+	MigrationBatchSize = 4096
+)
+
+var (
+	ErrNewerSchema      = errors.New("database schema is newer than supported, upgrade gamc")
+	ErrOutdatedSchema   = errors.New("database schema is outdated, run gamc db migrate")
+	ErrInvalidSchema    = errors.New("database schema version is invalid")
+	ErrMissingMigration = errors.New("no migration registered for the database schema")
+)
+
+// Migration upgrade a database from the schema Version-1 to Version in place.
+type Migration struct {
+	Version uint32
+	Name    string
+	Migrate func(db cdb.Storage) error
+}
+
+// migrations the registered migrations, ordered by version.
+var migrations = []*Migration{
+	{Version: 1, Name: "split the flat key space into tables", Migrate: migrateToTables},
+}
+
+// ReadSchemaVersion returns the schema version of db, 0 if db predates the schema versioning.
+func ReadSchemaVersion(db cdb.Storage) (uint32, error) {
+	value, err := cdb.NewTable(db, MetaTable).Get([]byte(SchemaVersionKey))
+	if err == cdb.ErrKeyNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(value) != 4 {
+		return 0, ErrInvalidSchema
+	}
+	return byteutils.Uint32(value), nil
+}
+
+func writeSchemaVersion(db cdb.Storage, version uint32) error {
+	return cdb.NewTable(db, MetaTable).Put([]byte(SchemaVersionKey), byteutils.FromUint32(version))
+}
+
+// InitSchema check the schema version of db on startup. An empty db is stamped
+// with SchemaVersion, an older db must be migrated with MigrateSchema first and
+// a newer one is refused.
+func InitSchema(db cdb.Storage) error {
+	version, err := ReadSchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		it := db.NewIterator()
+		empty := !it.Next()
+		it.Release()
+		if empty {
+			return writeSchemaVersion(db, SchemaVersion)
+		}
+	}
+
+	if version > SchemaVersion {
+		logging.CLog().WithFields(logrus.Fields{
+			"version":   version,
+			"supported": SchemaVersion,
+		}).Error("Database schema is newer than supported.")
+		return ErrNewerSchema
+	}
+	if version < SchemaVersion {
+		logging.CLog().WithFields(logrus.Fields{
+			"version": version,
+			"current": SchemaVersion,
+		}).Error("Database schema is outdated.")
+		return ErrOutdatedSchema
+	}
+	return nil
+}
+
+// MigrateSchema upgrade db in place to SchemaVersion, running the registered
+// migrations in order. The version is written after each migration, so an
+// interrupted upgrade resumes from the last completed one. It returns the
+// version db had before.
+func MigrateSchema(db cdb.Storage) (uint32, error) {
+	from, err := ReadSchemaVersion(db)
+	if err != nil {
+		return 0, err
+	}
+	if from > SchemaVersion {
+		return from, ErrNewerSchema
+	}
+
+	for version := from + 1; version <= SchemaVersion; version++ {
+		var migration *Migration
+		for _, m := range migrations {
+			if m.Version == version {
+				migration = m
+			}
+		}
+		if migration == nil {
+			return from, ErrMissingMigration
+		}
+
+		logging.CLog().WithFields(logrus.Fields{
+			"version":   version,
+			"migration": migration.Name,
+		}).Info("Migrating database schema.")
+		if err := migration.Migrate(db); err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"version":   version,
+				"migration": migration.Name,
+				"err":       err,
+			}).Error("Failed to migrate database schema.")
+			return from, err
+		}
+		if err := writeSchemaVersion(db, version); err != nil {
+			return from, err
+		}
+		if err := db.Flush(); err != nil {
+			return from, err
+		}
+	}
+	return from, nil
+}
+
+// legacy keys of the flat key space.
+const (
+	legacyTail          = "blockchain_tail"
+	legacyFixed         = "blockchain_fixed"
+	legacyReindex       = "blockchain_reindex"
+	legacyTxIndexPrefix = "blockchain_tx_"
+)
+
+// migrateToTables move the keys of the flat key space into the tables: the chain
+// pointers into MetaTable, the tx indices into TxIndexTable, the 8 bytes heights
+// into HeightTable, the trie nodes, whose key is the hash of their value, into
+// TrieTable and the other hashes, the blocks, into BlockTable.
+func migrateToTables(db cdb.Storage) error {
+	// collect the keys first, the storage can't be modified while iterated.
+	var keys [][]byte
+	it := db.NewIterator()
+	for it.Next() {
+		keys = append(keys, append([]byte{}, it.Key()...))
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	tables := newChainTables(db)
+	db.EnableBatch()
+	defer db.DisableBatch()
+
+	moved, skipped := 0, 0
+	for i, key := range keys {
+		value, err := db.Get(key)
+		if err != nil {
+			return err
+		}
+
+		var (
+			table cdb.Storage
+			k     = key
+		)
+		switch s := string(key); {
+		case s == legacyTail:
+			table, k = tables.meta, []byte(Tail)
+		case s == legacyFixed:
+			table, k = tables.meta, []byte(FIXED)
+		case s == legacyReindex:
+			table, k = tables.meta, []byte(Reindex)
+		case strings.HasPrefix(s, legacyTxIndexPrefix):
+			table, k = tables.txIndices, key[len(legacyTxIndexPrefix):]
+		case len(key) == 8:
+			table = tables.heights
+		case len(key) == 32 && bytes.Equal(hash.Sha3256(value), key):
+			table = tables.tries
+		case len(key) == 32:
+			table = tables.blocks
+		default:
+			// unknown, or already moved by an interrupted migration.
+			skipped++
+			logging.VLog().WithFields(logrus.Fields{
+				"key": byteutils.Hex(key),
+			}).Debug("Skipped key while migrating database.")
+			continue
+		}
+
+		if err := table.Put(k, value); err != nil {
+			return err
+		}
+		if err := db.Delete(key); err != nil {
+			return err
+		}
+		moved++
+
+		if (i+1)%MigrationBatchSize == 0 {
+			if err := db.Flush(); err != nil {
+				return err
+			}
+			logging.CLog().WithFields(logrus.Fields{
+				"done":  i + 1,
+				"total": len(keys),
+			}).Info("Migrating keys into tables.")
+		}
+	}
+	if err := db.Flush(); err != nil {
+		return err
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"moved":   moved,
+		"skipped": skipped,
+	}).Info("Migrated keys into tables.")
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package core
+
+import (
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/trie"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrReadOnlySnapshot = errors.New("cannot write to a world state snapshot")
+)
+
+// Snapshot is an immutable view of the world state at given roots. It reads
+// the committed trie nodes directly, bypassing the MVCCDB of the world state,
+// so it may be used by many goroutines while the chain executes and commits.
+type Snapshot interface {
+	AccountsRoot() byteutils.Hash
+	TxsRoot() byteutils.Hash
+
+	Accounts() ([]Account, error)
+	GetOrCreateAccount(addr byteutils.Hash) (Account, error)
+
+	GetTx(txHash byteutils.Hash) ([]byte, error)
+}
+
+// snapshot implements Snapshot. It holds no mutable state: each read walks
+// the tries from their roots, and each account returned is a private copy
+// whose contract storage cannot be written.
+type snapshot struct {
+	accState     *trie.Trie
+	txsState     *trie.Trie
+	storage      cdb.Storage
+	accountsRoot byteutils.Hash
+	txsRoot      byteutils.Hash
+}
+
+// readOnlyStorage rejects the writes to the tries through a snapshot, so
+// changing an account got from a snapshot never leaks nodes into the tries.
+type readOnlyStorage struct {
+	cdb.Storage
+}
+
+func (s *readOnlyStorage) Put(key []byte, value []byte) error {
+	return ErrReadOnlySnapshot
+}
+
+func (s *readOnlyStorage) Delete(key []byte) error {
+	return ErrReadOnlySnapshot
+}
+
+func (s *readOnlyStorage) DeleteRange(start, limit []byte) error {
+	return ErrReadOnlySnapshot
+}
+
+// NewWriteBatch returns a batch rejecting the writes, the tries commit their
+// dirty nodes through a batch.
+func (s *readOnlyStorage) NewWriteBatch() cdb.WriteBatch {
+	return readOnlyBatch{}
+}
+
+// readOnlyBatch rejects the writes to a snapshot batched by a trie commit.
+type readOnlyBatch struct{}
+
+func (b readOnlyBatch) Put(key []byte, value []byte) error {
+	return ErrReadOnlySnapshot
+}
+
+func (b readOnlyBatch) Delete(key []byte) error {
+	return ErrReadOnlySnapshot
+}
+
+func (b readOnlyBatch) Len() int     { return 0 }
+func (b readOnlyBatch) Write() error { return ErrReadOnlySnapshot }
+func (b readOnlyBatch) Reset()       {}
+
+// newSnapshot returns the snapshot of the committed tries at accountsRoot and
+// txsRoot, nil roots standing for empty tries.
+func newSnapshot(tries cdb.Storage, accountsRoot, txsRoot byteutils.Hash) (*snapshot, error) {
+	storage := &readOnlyStorage{tries}
+	accState, err := trie.NewTrie(accountsRoot, storage, false)
+	if err != nil {
+		return nil, err
+	}
+	txsState, err := trie.NewTrie(txsRoot, storage, false)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		accState:     accState,
+		txsState:     txsState,
+		storage:      storage,
+		accountsRoot: accountsRoot,
+		txsRoot:      txsRoot,
+	}, nil
+}
+
+// AccountsRoot return the accounts root of the snapshot, the tries of a
+// snapshot are never changed, so their roots are kept as given.
+func (s *snapshot) AccountsRoot() byteutils.Hash {
+	return s.accountsRoot
+}
+
+// TxsRoot return the txs root of the snapshot.
+func (s *snapshot) TxsRoot() byteutils.Hash {
+	return s.txsRoot
+}
+
+func (s *snapshot) Accounts() ([]Account, error) {
+	accounts := []Account{}
+	iter, err := s.accState.Iterator(nil)
+	if err != nil && err != cdb.ErrKeyNotFound {
+		return nil, err
+	}
+	if err != nil {
+		return accounts, nil
+	}
+	exist, err := iter.Next()
+	for ; err == nil && exist; exist, err = iter.Next() {
+		acc := new(account)
+		if err := acc.FromBytes(iter.Value(), s.storage); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// GetOrCreateAccount returns the account of addr, or an empty account if it
+// doesn't exist at the snapshot.
+func (s *snapshot) GetOrCreateAccount(addr byteutils.Hash) (Account, error) {
+	bytes, err := s.accState.Get(addr)
+	if err != nil && err != cdb.ErrKeyNotFound {
+		return nil, err
+	}
+	if err == cdb.ErrKeyNotFound {
+		variables, err := trie.NewTrie(nil, s.storage, false)
+		if err != nil {
+			return nil, err
+		}
+		return &account{
+			address:     addr,
+			balance:     big.NewInt(0),
+			frozenFund:  big.NewInt(0),
+			pledgeFund:  big.NewInt(0),
+			variables:   variables,
+			creditIndex: big.NewInt(0),
+		}, nil
+	}
+	acc := new(account)
+	if err := acc.FromBytes(bytes, s.storage); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+func (s *snapshot) GetTx(txHash byteutils.Hash) ([]byte, error) {
+	return s.txsState.Get(txHash)
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"testing"
+
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+)
+
+func TestSnapshotAccountIsReadOnly(t *testing.T) {
+	tries, _ := cdb.NewMemoryStorage()
+	snap, err := newSnapshot(tries, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acc, err := snap.GetOrCreateAccount(testAddress(t, "account").Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the contract storage changes in memory, but can't be committed.
+	if err := acc.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	if err := acc.(*account).variables.Commit(); err != ErrReadOnlySnapshot {
+		t.Fatalf("commit of snapshot account: %v, want %v", err, ErrReadOnlySnapshot)
+	}
+
+	it := tries.NewIterator()
+	defer it.Release()
+	if it.Next() {
+		t.Fatalf("snapshot wrote %x to the tries", it.Key())
+	}
+}
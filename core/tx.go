@@ -19,6 +19,8 @@ package core
 
 import (
 	corepb "gamc.pro/gamcio/go-gamc/core/pb"
+	"gamc.pro/gamcio/go-gamc/crypto"
+	"gamc.pro/gamcio/go-gamc/crypto/keystore"
 	"gamc.pro/gamcio/go-gamc/util/byteutils"
 	"gamc.pro/gamcio/go-gamc/util/logging"
 	"encoding/hex"
@@ -29,6 +31,11 @@ import (
 	"time"
 )
 
+const (
+	// TxPayloadBinaryType binary transaction payload
+	TxPayloadBinaryType = "binary"
+)
+
 // Transaction
 type Transaction struct {
 	hash      byteutils.Hash
@@ -76,9 +83,29 @@ func newTransaction(nonce uint64, from, to *Address, amount *big.Int) *Transacti
 	return &tx
 }
 
-func (tx *Transaction) Nonce() uint64        { return tx.nonce }
-func (tx *Transaction) Hash() byteutils.Hash { return tx.hash }
-func (tx *Transaction) Timestamp() int64     { return tx.timestamp }
+func (tx *Transaction) Nonce() uint64                { return tx.nonce }
+func (tx *Transaction) Hash() byteutils.Hash         { return tx.hash }
+func (tx *Transaction) Timestamp() int64             { return tx.timestamp }
+func (tx *Transaction) ChainId() uint32              { return tx.chainId }
+func (tx *Transaction) Priority() uint32             { return tx.priority }
+func (tx *Transaction) Data() *corepb.Data           { return tx.data }
+func (tx *Transaction) Signature() *corepb.Signature { return tx.sign }
+
+// Value return the transfer amount of the transaction.
+func (tx *Transaction) Value() *big.Int {
+	if tx.value == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(tx.value)
+}
+
+// Fee return the fee of the transaction.
+func (tx *Transaction) Fee() *big.Int {
+	if tx.fee == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(tx.fee)
+}
 
 // TxFrom
 func (tx *Transaction) From() *Address {
@@ -138,6 +165,7 @@ func (tx *Transaction) FromProto(msg proto.Message) error {
 			tx.data = msg.Data
 			tx.priority = msg.Priority
 			tx.sign = msg.Sign
+			return nil
 		}
 		return ErrInvalidProtoToTransaction
 	}
@@ -165,7 +193,31 @@ func (tx *Transaction) VerifyIntegrity(chainId uint32) error {
 
 }
 
+// Sign calculate the hash of the transaction and sign it.
+func (tx *Transaction) Sign(signature keystore.Signature) error {
+	if signature == nil {
+		return ErrNilArgument
+	}
+	hash, err := tx.calcHash()
+	if err != nil {
+		return err
+	}
+	sign, err := signature.Sign(hash)
+	if err != nil {
+		return err
+	}
+	tx.hash = hash
+	tx.sign = &corepb.Signature{
+		Signer: sign.GetSigner(),
+		Data:   sign.GetData(),
+	}
+	return nil
+}
+
 func (tx *Transaction) verifySign() error {
+	if tx.sign == nil {
+		return ErrMissingTransactionSign
+	}
 	signer, err := NewAddressFromPublicKey(tx.sign.Signer)
 	if err != nil {
 		logging.VLog().WithFields(logrus.Fields{
@@ -180,6 +232,23 @@ func (tx *Transaction) verifySign() error {
 		}).Debug("Failed to verify tx's sign.")
 		return ErrInvalidTransactionSigner
 	}
+
+	// the signer matches from, check it signed the hash.
+	signature, err := crypto.NewSignature()
+	if err != nil {
+		return err
+	}
+	verified, err := signature.Verify(tx.hash, tx.sign)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		logging.VLog().WithFields(logrus.Fields{
+			"tx.hash": tx.hash,
+			"tx.from": tx.from,
+		}).Debug("Failed to verify tx's sign.")
+		return ErrInvalidTransactionSign
+	}
 	return nil
 }
 
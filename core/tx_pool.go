@@ -21,6 +21,7 @@ import (
 	"container/heap"
 	corepb "gamc.pro/gamcio/go-gamc/core/pb"
 	"gamc.pro/gamcio/go-gamc/network"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
 	"gamc.pro/gamcio/go-gamc/util/logging"
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
@@ -33,7 +34,8 @@ const (
 )
 
 var (
-	ErrTxPoolFull = errors.New("tx pool is full")
+	ErrTxPoolFull            = errors.New("tx pool is full")
+	ErrDuplicatedTransaction = errors.New("duplicated transaction")
 )
 
 // TxPool
@@ -43,24 +45,41 @@ type TxPool struct {
 	txFilter  map[string]map[uint64]struct{} // address --> map[txNonce]struct{}
 	quitCh    chan int
 	recvMsgCh chan network.Message
+	ns        network.Service
+	emitter   *EventEmitter
+	bc        *BlockChain
 	rw        sync.RWMutex
 }
 
 func NewTxPool() *TxPool {
 	return &TxPool{
-		pending:  timeHeap{},
-		queued:   timeHeap{},
-		txFilter: make(map[string]map[uint64]struct{}),
-		quitCh:   make(chan int),
+		pending:   timeHeap{},
+		queued:    timeHeap{},
+		txFilter:  make(map[string]map[uint64]struct{}),
+		quitCh:    make(chan int, 1),
+		recvMsgCh: make(chan network.Message, maxPendingSize),
 	}
 }
 
+// RegisterInNetwork register message subscriber in network.
+func (pool *TxPool) RegisterInNetwork(ns network.Service) {
+	ns.Register(network.NewSubscriber(pool, pool.recvMsgCh, true, MessageTypeNewTx, network.MessageWeightNewTx))
+	pool.ns = ns
+}
+
 func (pool *TxPool) Start() {
 	logging.CLog().WithFields(logrus.Fields{}).Info("Starting TransactionPool...")
 
 	go pool.loop()
 }
 
+// Stop stop loop.
+func (pool *TxPool) Stop() {
+	logging.CLog().WithFields(logrus.Fields{}).Info("Stopping TransactionPool...")
+
+	pool.quitCh <- 0
+}
+
 func (pool *TxPool) loop() {
 	for {
 		select {
@@ -94,7 +113,7 @@ func (pool *TxPool) loop() {
 				}).Debug("Failed to recover a tx from proto data.")
 				continue
 			}
-			if err := pool.add(tx, true); err != nil {
+			if err := pool.addReceived(tx); err != nil {
 				logging.VLog().WithFields(logrus.Fields{
 					"func":        "TxPool.loop",
 					"messageType": msg.MessageType(),
@@ -110,6 +129,8 @@ func (pool *TxPool) loop() {
 
 // GetAccountTxAmount
 func (pool *TxPool) GetAccountTxAmount(address Address) int {
+	pool.rw.RLock()
+	defer pool.rw.RUnlock()
 	return len(pool.txFilter[address.String()])
 }
 
@@ -117,7 +138,11 @@ func (pool *TxPool) GetAccountTxAmount(address Address) int {
 func (pool *TxPool) Get(size int) []*Transaction {
 	pool.rw.Lock()
 	defer pool.rw.Unlock()
-	return pool.pending.PopTxs(size)
+	txs := pool.pending.PopTxs(size)
+	for _, tx := range txs {
+		pool.removeTx(tx)
+	}
+	return txs
 }
 
 // AddRemote
@@ -130,8 +155,70 @@ func (pool *TxPool) AddLocal(txs []*Transaction) []error {
 	return pool.addTxs(txs, true)
 }
 
+func (pool *TxPool) setEventEmitter(emitter *EventEmitter) {
+	pool.emitter = emitter
+}
+
+func (pool *TxPool) setBlockChain(bc *BlockChain) {
+	pool.bc = bc
+}
+
+// addReceived verify a tx received from the network and add it into the pool.
+func (pool *TxPool) addReceived(tx *Transaction) error {
+	if err := tx.VerifyIntegrity(pool.bc.ChainId()); err != nil {
+		return err
+	}
+
+	pool.rw.Lock()
+	defer pool.rw.Unlock()
+
+	if pool.isExist(tx) {
+		return ErrDuplicatedTransaction
+	}
+	return pool.add(tx, true)
+}
+
+// AddAndBroadcast add a local tx into the pool and broadcast it to the peers.
+func (pool *TxPool) AddAndBroadcast(tx *Transaction) error {
+	if tx == nil {
+		return ErrNilArgument
+	}
+
+	pool.rw.Lock()
+	defer pool.rw.Unlock()
+
+	if pool.isExist(tx) {
+		return ErrDuplicatedTransaction
+	}
+	if err := pool.add(tx, true); err != nil {
+		return err
+	}
+
+	if pool.ns != nil {
+		pool.ns.Broadcast(MessageTypeNewTx, tx, network.MessagePriorityNormal)
+	}
+	return nil
+}
+
+// GetTransaction return the tx with the given hash in the pool, nil if not found.
+func (pool *TxPool) GetTransaction(hash byteutils.Hash) *Transaction {
+	pool.rw.RLock()
+	defer pool.rw.RUnlock()
+
+	for _, txs := range []timeHeap{pool.pending, pool.queued} {
+		for _, tx := range txs {
+			if tx.Hash().Equals(hash) {
+				return tx
+			}
+		}
+	}
+	return nil
+}
+
 // Promote
 func (pool *TxPool) Promote(size int) {
+	pool.rw.Lock()
+	defer pool.rw.Unlock()
 	pool.promote(size)
 }
 
@@ -147,6 +234,9 @@ func (pool *TxPool) isExist(tx *Transaction) bool {
 
 // addTxs
 func (pool *TxPool) addTxs(txs []*Transaction, local bool) []error {
+	pool.rw.Lock()
+	defer pool.rw.Unlock()
+
 	errs := make([]error, 0)
 	for _, tx := range txs {
 		if pool.isExist(tx) {
@@ -172,6 +262,15 @@ func (pool *TxPool) add(tx *Transaction, local bool) error {
 		heap.Push(&pool.queued, tx)
 	}
 
+	from := tx.From().String()
+	if _, ok := pool.txFilter[from]; !ok {
+		pool.txFilter[from] = make(map[uint64]struct{})
+	}
+	pool.txFilter[from][tx.Nonce()] = struct{}{}
+
+	if pool.emitter != nil {
+		pool.emitter.Trigger(&Event{Topic: TopicPendingTransaction, Transaction: tx})
+	}
 	return nil
 }
 
@@ -188,6 +287,9 @@ func (pool *TxPool) removeTx(tx *Transaction) {
 	if !pool.isExist(tx) {
 		return
 	}
-	from := *tx.From()
-	delete(pool.txFilter[from.String()], tx.Nonce())
+	from := tx.From().String()
+	delete(pool.txFilter[from], tx.Nonce())
+	if len(pool.txFilter[from]) == 0 {
+		delete(pool.txFilter, from)
+	}
 }
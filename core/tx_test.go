@@ -0,0 +1,94 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	corepb "gamc.pro/gamcio/go-gamc/core/pb"
+	"gamc.pro/gamcio/go-gamc/crypto"
+)
+
+// newTestSignedTx return a tx from the address of a new key, signed by it.
+func newTestSignedTx(t *testing.T, to *Address) *Transaction {
+	priv, err := crypto.NewPrivateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := priv.PublicKey().Encoded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, err := NewAddressFromPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx := new(Transaction)
+	if err := tx.FromProto(&corepb.Transaction{
+		From:      from.Bytes(),
+		To:        to.Bytes(),
+		Value:     big.NewInt(1).Bytes(),
+		ChainId:   23,
+		Timestamp: GenesisTimestamp,
+		Data:      &corepb.Data{Type: TxPayloadBinaryType},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	signature, err := crypto.NewSignature()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signature.InitSign(priv); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Sign(signature); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestTransactionVerifySign(t *testing.T) {
+	tx := newTestSignedTx(t, testAddress(t, "to"))
+	if err := tx.VerifyIntegrity(23); err != nil {
+		t.Fatalf("verify signed tx: %v", err)
+	}
+
+	// a signature changed in transit.
+	data := append([]byte{}, tx.sign.Data...)
+	tx.sign.Data[0] ^= 0xff
+	if err := tx.VerifyIntegrity(23); err != ErrInvalidTransactionSign {
+		t.Fatalf("verify tx with changed signature: %v, want %v", err, ErrInvalidTransactionSign)
+	}
+	tx.sign.Data = data
+
+	// the public key of from, known to anyone, with the signature of another tx.
+	other := newTestSignedTx(t, testAddress(t, "to"))
+	tx.sign = &corepb.Signature{Signer: tx.sign.Signer, Data: other.sign.Data}
+	if err := tx.VerifyIntegrity(23); err != ErrInvalidTransactionSign {
+		t.Fatalf("verify forged tx: %v, want %v", err, ErrInvalidTransactionSign)
+	}
+
+	// a malformed public key.
+	tx.sign = &corepb.Signature{Signer: []byte{1, 2, 3}, Data: data}
+	if err := tx.VerifyIntegrity(23); err == nil {
+		t.Fatal("verified tx with a malformed signer")
+	}
+}
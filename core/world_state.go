@@ -29,6 +29,7 @@ import (
 type WorldState interface {
 	Begin() error
 	Commit() error
+	CommitTo(batch cdb.WriteBatch) error
 	RollBack() error
 
 	Prepare(interface{}) (TxWorldState, error)
@@ -43,9 +44,10 @@ type WorldState interface {
 	LoadTxsRoot(byteutils.Hash) error
 
 	Clone() (WorldState, error)
+	Snapshot(accountsRoot, txsRoot byteutils.Hash) (Snapshot, error)
 
-	AccountsRoot() byteutils.Hash
-	TxsRoot() byteutils.Hash
+	AccountsRoot() (byteutils.Hash, error)
+	TxsRoot() (byteutils.Hash, error)
 
 	Accounts() ([]Account, error)
 	GetOrCreateAccount(addr byteutils.Hash) (Account, error)
@@ -59,13 +61,17 @@ type WorldState interface {
 
 // TxWorldState is the world state of a single transaction
 type TxWorldState interface {
-	AccountsRoot() byteutils.Hash
-	TxsRoot() byteutils.Hash
+	AccountsRoot() (byteutils.Hash, error)
+	TxsRoot() (byteutils.Hash, error)
 
 	CheckAndUpdate() ([]interface{}, error)
 	Reset(addr byteutils.Hash, isResetChangeLog bool) error
 	Close() error
 
+	Savepoint() (int, error)
+	RollBackToSavepoint(savepoint int) error
+	ReleaseSavepoint(savepoint int) error
+
 	Accounts() ([]Account, error)
 	GetOrCreateAccount(addr byteutils.Hash) (Account, error)
 
@@ -76,12 +82,12 @@ type TxWorldState interface {
 	GetBlock(txHash byteutils.Hash) ([]byte, error)
 }
 
-func newStates(stor cdb.Storage) (*states, error) {
+func newStates(tables *chainTables) (*states, error) {
 	changelog, err := newChangeLog()
 	if err != nil {
 		return nil, err
 	}
-	stateDB, err := newStateDB(stor)
+	stateDB, err := newStateDB(tables.tries)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +107,7 @@ func newStates(stor cdb.Storage) (*states, error) {
 		txsState:  txsState,
 		changelog: changelog,
 		stateDB:   stateDB,
-		innerDB:   stor,
+		tables:    tables,
 		txid:      nil,
 	}, nil
 }
@@ -130,16 +136,23 @@ type states struct {
 	consensusState ConsensusState
 	changelog      *mvccdb.MVCCDB
 	stateDB        *mvccdb.MVCCDB
-	innerDB        cdb.Storage
+	tables         *chainTables
 	txid           interface{}
 }
 
+// savepoint the states of a transaction to revert a failed sub-call to.
+type savepoint struct {
+	stateDB  int
+	accounts map[byteutils.HexHash]*account
+	txs      trie.Revision
+}
+
 func (s *states) Replay(done *states) error {
 	err := s.accState.Replay(done.accState)
 	if err != nil {
 		return err
 	}
-	_, err = s.txsState.Replay(done.txsState)
+	err = s.txsState.Replay(done.txsState)
 	if err != nil {
 		return err
 	}
@@ -151,17 +164,26 @@ func (s *states) Clone() (*states, error) {
 	if err != nil {
 		return nil, err
 	}
-	stateDB, err := newStateDB(s.innerDB)
+	stateDB, err := newStateDB(s.tables.tries)
+	if err != nil {
+		return nil, err
+	}
+
+	accountsRoot, err := s.AccountsRoot()
+	if err != nil {
+		return nil, err
+	}
+	txsRoot, err := s.TxsRoot()
 	if err != nil {
 		return nil, err
 	}
 
-	accState, err := NewAccountState(s.accState.RootHash(), stateDB)
+	accState, err := NewAccountState(accountsRoot, stateDB)
 	if err != nil {
 		return nil, err
 	}
 
-	txsState, err := trie.NewTrie(s.txsState.RootHash(), stateDB, false)
+	txsState, err := trie.NewTrie(txsRoot, stateDB, false)
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +194,7 @@ func (s *states) Clone() (*states, error) {
 
 		changelog: changelog,
 		stateDB:   stateDB,
-		innerDB:   s.innerDB,
+		tables:    s.tables,
 		txid:      s.txid,
 	}, nil
 }
@@ -188,15 +210,27 @@ func (s *states) Begin() error {
 }
 
 func (s *states) Commit() error {
+	batch := s.tables.tries.NewWriteBatch()
+	if err := s.CommitTo(batch); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// CommitTo commit the trie nodes into batch, a write batch of the tries table.
+func (s *states) CommitTo(batch cdb.WriteBatch) error {
 	if err := s.Flush(); err != nil {
 		return err
 	}
+	if err := s.commitTries(); err != nil {
+		return err
+	}
 	// changelog is used to check conflict temporarily
 	// we should rollback it when the transaction is over
 	if err := s.changelog.RollBack(); err != nil {
 		return err
 	}
-	if err := s.stateDB.Commit(); err != nil {
+	if err := s.stateDB.CommitTo(batch); err != nil {
 		return err
 	}
 
@@ -232,13 +266,25 @@ func (s *states) Prepare(txid interface{}) (*states, error) {
 	if err := s.Flush(); err != nil {
 		return nil, err
 	}
+	if err := s.commitTries(); err != nil {
+		return nil, err
+	}
 
-	accState, err := NewAccountState(s.AccountsRoot(), stateDB)
+	accountsRoot, err := s.AccountsRoot()
+	if err != nil {
+		return nil, err
+	}
+	txsRoot, err := s.TxsRoot()
 	if err != nil {
 		return nil, err
 	}
 
-	txsState, err := trie.NewTrie(s.TxsRoot(), stateDB, true)
+	accState, err := NewAccountState(accountsRoot, stateDB)
+	if err != nil {
+		return nil, err
+	}
+
+	txsState, err := trie.NewTrie(txsRoot, stateDB, true)
 	if err != nil {
 		return nil, err
 	}
@@ -249,12 +295,16 @@ func (s *states) Prepare(txid interface{}) (*states, error) {
 
 		changelog: changelog,
 		stateDB:   stateDB,
-		innerDB:   s.innerDB,
+		tables:    s.tables,
 		txid:      txid,
 	}, nil
 }
 
 func (s *states) CheckAndUpdateTo(parent *states) ([]interface{}, error) {
+	// the parent takes the dirty accounts, their storage nodes are merged with the stateDB.
+	if err := s.accState.(*accountState).commitAccounts(); err != nil {
+		return nil, err
+	}
 	dependency, err := s.changelog.CheckAndUpdate()
 	if err != nil {
 		return nil, err
@@ -297,6 +347,14 @@ func (s *states) Flush() error {
 	return s.accState.Flush()
 }
 
+// commitTries write the dirty nodes of the tries into the stateDB.
+func (s *states) commitTries() error {
+	if err := s.accState.Commit(); err != nil {
+		return err
+	}
+	return s.txsState.Commit()
+}
+
 func (s *states) Abort() error {
 	// TODO: Abort txsState, eventsState, consensusState
 	// we don't need to abort the three states now
@@ -320,11 +378,11 @@ func (s *states) Close() error {
 	return nil
 }
 
-func (s *states) AccountsRoot() byteutils.Hash {
+func (s *states) AccountsRoot() (byteutils.Hash, error) {
 	return s.accState.RootHash()
 }
 
-func (s *states) TxsRoot() byteutils.Hash {
+func (s *states) TxsRoot() (byteutils.Hash, error) {
 	return s.txsState.RootHash()
 }
 
@@ -362,7 +420,7 @@ func (s *states) GetTx(txHash byteutils.Hash) ([]byte, error) {
 }
 
 func (s *states) PutTx(txHash byteutils.Hash, txBytes []byte) error {
-	_, err := s.txsState.Put(txHash, txBytes)
+	err := s.txsState.Put(txHash, txBytes)
 	if err != nil {
 		return err
 	}
@@ -387,8 +445,14 @@ func (s *states) LoadTxsRoot(root byteutils.Hash) error {
 	return nil
 }
 
+// Snapshot returns a read-only view of the committed states at the roots,
+// which doesn't share the MVCCDB of the states.
+func (s *states) Snapshot(accountsRoot, txsRoot byteutils.Hash) (Snapshot, error) {
+	return newSnapshot(s.tables.tries, accountsRoot, txsRoot)
+}
+
 func (s *states) GetBlockHashByHeight(height uint64) ([]byte, error) {
-	bytes, err := s.innerDB.Get(byteutils.FromUint64(height))
+	bytes, err := s.tables.heights.Get(byteutils.FromUint64(height))
 	if err != nil {
 		return nil, err
 	}
@@ -396,7 +460,7 @@ func (s *states) GetBlockHashByHeight(height uint64) ([]byte, error) {
 }
 
 func (s *states) GetBlock(hash byteutils.Hash) ([]byte, error) {
-	bytes, err := s.innerDB.Get(hash)
+	bytes, err := s.tables.blocks.Get(hash)
 	if err != nil {
 		return nil, err
 	}
@@ -405,7 +469,13 @@ func (s *states) GetBlock(hash byteutils.Hash) ([]byte, error) {
 
 // NewWorldState create a new empty WorldState
 func NewWorldState(storage cdb.Storage) (WorldState, error) {
-	states, err := newStates(storage)
+	return newWorldState(newChainTables(storage))
+}
+
+// newWorldState create a new empty WorldState on the tables of a chain,
+// sharing their caches.
+func newWorldState(tables *chainTables) (WorldState, error) {
+	states, err := newStates(tables)
 	if err != nil {
 		return nil, err
 	}
@@ -455,6 +525,16 @@ func (ws *worldState) Commit() error {
 	return nil
 }
 
+// CommitTo commit the changes into batch, a write batch of the tries table,
+// which the caller writes.
+func (ws *worldState) CommitTo(batch cdb.WriteBatch) error {
+	if err := ws.states.CommitTo(batch); err != nil {
+		return err
+	}
+	ws.snapshot = nil
+	return nil
+}
+
 func (ws *worldState) RollBack() error {
 	if err := ws.states.RollBack(); err != nil {
 		return err
@@ -479,8 +559,9 @@ func (ws *worldState) Prepare(txid interface{}) (TxWorldState, error) {
 
 type txWorldState struct {
 	*states
-	txid   interface{}
-	parent *worldState
+	txid       interface{}
+	parent     *worldState
+	savepoints []*savepoint
 }
 
 func (tws *txWorldState) CheckAndUpdate() ([]interface{}, error) {
@@ -496,6 +577,7 @@ func (tws *txWorldState) Reset(addr byteutils.Hash, isResetChangeLog bool) error
 	if err := tws.states.Reset(addr, isResetChangeLog); err != nil {
 		return err
 	}
+	tws.savepoints = nil
 	return nil
 }
 
@@ -507,6 +589,61 @@ func (tws *txWorldState) Close() error {
 	return nil
 }
 
+// Savepoint mark the states of the transaction, the changes made after it can
+// be reverted by RollBackToSavepoint, keeping the ones made before, e.g. the fee.
+// The accounts got after a savepoint must be got again once rolled back to it.
+func (tws *txWorldState) Savepoint() (int, error) {
+	accounts, err := tws.accState.(*accountState).savepoint()
+	if err != nil {
+		return 0, err
+	}
+	// the changelog is kept as is, the accounts touched by a reverted sub-call
+	// are still the dependencies of the transaction.
+	id, err := tws.stateDB.Savepoint()
+	if err != nil {
+		return 0, err
+	}
+	txs, err := tws.txsState.Revision()
+	if err != nil {
+		return 0, err
+	}
+	tws.savepoints = append(tws.savepoints, &savepoint{
+		stateDB:  id,
+		accounts: accounts,
+		txs:      txs,
+	})
+	return len(tws.savepoints) - 1, nil
+}
+
+// RollBackToSavepoint revert the changes made after the savepoint, and release
+// it with the savepoints nested in it.
+func (tws *txWorldState) RollBackToSavepoint(id int) error {
+	if id < 0 || id >= len(tws.savepoints) {
+		return mvccdb.ErrInvalidSavepoint
+	}
+	sp := tws.savepoints[id]
+	if err := tws.stateDB.RollBackToSavepoint(sp.stateDB); err != nil {
+		return err
+	}
+	tws.accState.(*accountState).rollBackTo(sp.accounts)
+	tws.txsState.RevertTo(sp.txs)
+	tws.savepoints = tws.savepoints[:id]
+	return nil
+}
+
+// ReleaseSavepoint release the savepoint with the savepoints nested in it,
+// keeping the changes made after it.
+func (tws *txWorldState) ReleaseSavepoint(id int) error {
+	if id < 0 || id >= len(tws.savepoints) {
+		return mvccdb.ErrInvalidSavepoint
+	}
+	if err := tws.stateDB.ReleaseSavepoint(tws.savepoints[id].stateDB); err != nil {
+		return err
+	}
+	tws.savepoints = tws.savepoints[:id]
+	return nil
+}
+
 func (tws *txWorldState) TxID() interface{} {
 	return tws.txid
 }
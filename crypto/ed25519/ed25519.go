@@ -47,8 +47,11 @@ func GetSeed(prikey []byte) ([]byte, error) {
 	return seed, nil
 }
 
-// Verify verify with public key
+// Verify verify with public key, false if the public key is malformed
 func Verify(pubKey []byte, message, sig []byte) bool {
+	if len(pubKey) != ed25519.PublicKeySize {
+		return false
+	}
 	return ed25519.Verify(pubKey, message, sig)
 }
 
@@ -53,5 +53,5 @@ func (k *PublicKey) Clear() {
 
 // Verify verify ecdsa publickey
 func (k *PublicKey) Verify(hash []byte, signature []byte) bool {
-	return Verify(hash, signature, k.pub)
+	return Verify(k.pub, hash, signature)
 }
@@ -0,0 +1,303 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package gamc
+
+import (
+	"gamc.pro/gamcio/go-gamc/account"
+	"gamc.pro/gamcio/go-gamc/conf"
+	"gamc.pro/gamcio/go-gamc/core"
+	"gamc.pro/gamcio/go-gamc/metrics"
+	"gamc.pro/gamcio/go-gamc/network"
+	"gamc.pro/gamcio/go-gamc/rpc"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	gsync "gamc.pro/gamcio/go-gamc/sync"
+	"gamc.pro/gamcio/go-gamc/util/config"
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"gamc.pro/gamcio/go-gamc/util/pprof"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+var (
+	ErrGamcAlreadyRunning = errors.New("gamc is already running")
+	ErrGamcNotSetup       = errors.New("gamc is not setup")
+	ErrNilConfig          = errors.New("nil config")
+	ErrGamcAlreadySetup   = errors.New("gamc is already setup")
+	ErrNoConsensus        = errors.New("no consensus engine is set, set chain.no_consensus to run without one in development")
+	ErrConsensusConflict  = errors.New("a consensus engine is set, unset chain.no_consensus to run it")
+)
+
+// Gamc holds all the services of a gamc node.
+type Gamc struct {
+	config *config.Config
+
+	storage           cdb.Storage
+	storageMaintainer *cdb.Maintainer
+	netService        network.Service
+	accountManager    *account.AccountManager
+	blockChain        *core.BlockChain
+	syncService       *gsync.Service
+	consensus         core.Consensus
+	rpcServer         *rpc.Server
+	pprof             *pprof.Pprof
+
+	// stoppers holds the stop functions of the started services,
+	// in the order they were started.
+	stoppers []func()
+	running  bool
+	lock     sync.Mutex
+}
+
+// New create a gamc node.
+func New(conf *config.Config) (*Gamc, error) {
+	if conf == nil {
+		return nil, ErrNilConfig
+	}
+	return &Gamc{config: conf}, nil
+}
+
+// SetConsensus set the consensus engine of the node, before Setup. The engine
+// is setup with the other services and started after the sync service.
+func (g *Gamc) SetConsensus(consensus core.Consensus) error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.blockChain != nil {
+		return ErrGamcAlreadySetup
+	}
+	g.consensus = consensus
+	return nil
+}
+
+// Setup build the services in dependency order.
+func (g *Gamc) Setup() error {
+	logging.CLog().Info("Setuping Gamc...")
+
+	var err error
+	g.storage, err = cdb.NewDB(g.config)
+	if err != nil {
+		return g.setupFailed("storage", err)
+	}
+	g.storageMaintainer = cdb.NewMaintainer(g.storage, cdb.GetDbConfig(g.config))
+
+	netService, err := network.NewgamcService(g.config)
+	if err != nil {
+		return g.setupFailed("network", err)
+	}
+	g.netService = netService
+
+	g.accountManager, err = account.NewAccountManager(g.config, g.storage)
+	if err != nil {
+		return g.setupFailed("account manager", err)
+	}
+
+	// running without consensus is for development only, it must be asked for.
+	noConsensus := conf.GetChainConfig(g.config).NoConsensus
+	switch {
+	case g.consensus != nil && noConsensus:
+		return g.setupFailed("consensus", ErrConsensusConflict)
+	case g.consensus == nil && !noConsensus:
+		return g.setupFailed("consensus", ErrNoConsensus)
+	case g.consensus == nil:
+		logging.CLog().Warn("Running without consensus for development, the blocks are accepted without the consensus check.")
+		g.consensus = core.NewNoConsensus()
+	}
+
+	if err = g.setupBlockChain(); err != nil {
+		return err
+	}
+
+	g.syncService = gsync.NewService(g.blockChain, g.netService)
+	g.blockChain.SetSyncEngine(g.syncService)
+
+	g.consensus.Setup(g)
+
+	g.rpcServer = rpc.NewServer(g)
+	g.pprof = &pprof.Pprof{Config: pprof.GetPprofConfig(g.config)}
+
+	logging.CLog().Info("Setuped Gamc.")
+	return nil
+}
+
+// SetupChain build the storage and the block chain only, without network,
+// for the offline tools working on the chain data. The offline tools never mine,
+// and the blocks they import are checked for integrity and by execution only.
+func (g *Gamc) SetupChain() error {
+	var err error
+	g.storage, err = cdb.NewDB(g.config)
+	if err != nil {
+		return g.setupFailed("storage", err)
+	}
+	if g.consensus == nil {
+		g.consensus = core.NewNoConsensus()
+	}
+	return g.setupBlockChain()
+}
+
+func (g *Gamc) setupBlockChain() error {
+	var err error
+	if err = core.InitSchema(g.storage); err != nil {
+		return g.setupFailed("storage", err)
+	}
+	g.blockChain, err = core.NewBlockChain(g.config, g.netService, g.storage)
+	if err != nil {
+		return g.setupFailed("blockchain", err)
+	}
+	if err = g.blockChain.Setup(g); err != nil {
+		return g.setupFailed("blockchain", err)
+	}
+	return nil
+}
+
+func (g *Gamc) setupFailed(service string, err error) error {
+	logging.CLog().WithFields(logrus.Fields{
+		"service": service,
+		"err":     err,
+	}).Error("Failed to setup Gamc.")
+	return err
+}
+
+// Start start the services in dependency order. If any service fails to
+// start, the ones already started are stopped again.
+func (g *Gamc) Start() error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if g.running {
+		return ErrGamcAlreadyRunning
+	}
+	if g.blockChain == nil {
+		return ErrGamcNotSetup
+	}
+	logging.CLog().Info("Starting Gamc...")
+	g.running = true
+
+	if metrics.GetStatsConfig(g.config).EnableMetrics {
+		metrics.Start(g.config)
+		g.stoppers = append(g.stoppers, metrics.Stop)
+	}
+
+	g.storageMaintainer.Start()
+	g.stoppers = append(g.stoppers, g.storageMaintainer.Stop)
+
+	g.pprof.StartProfiling()
+	g.stoppers = append(g.stoppers, g.pprof.StopProfiling)
+
+	if err := g.netService.Start(); err != nil {
+		g.stop()
+		return err
+	}
+	g.stoppers = append(g.stoppers, g.netService.Stop)
+
+	g.blockChain.Start()
+	g.stoppers = append(g.stoppers, g.blockChain.Stop)
+
+	g.blockChain.BlockPool().Start()
+	g.stoppers = append(g.stoppers, g.blockChain.BlockPool().Stop)
+
+	g.blockChain.TxPool().Start()
+	g.stoppers = append(g.stoppers, g.blockChain.TxPool().Stop)
+
+	g.syncService.Start()
+	g.stoppers = append(g.stoppers, g.syncService.Stop)
+
+	g.consensus.Start()
+	g.stoppers = append(g.stoppers, g.consensus.Stop)
+
+	if err := g.rpcServer.Start(); err != nil {
+		g.stop()
+		return err
+	}
+	g.stoppers = append(g.stoppers, g.rpcServer.Stop)
+
+	// catch up with the peers before doing anything else.
+	g.blockChain.StartActiveSync()
+
+	logging.CLog().Info("Started Gamc.")
+	return nil
+}
+
+// Stop stop the started services in reverse order, then flush and close the storage.
+func (g *Gamc) Stop() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	logging.CLog().Info("Stopping Gamc...")
+	g.stop()
+	logging.CLog().Info("Stopped Gamc.")
+}
+
+func (g *Gamc) stop() {
+	for i := len(g.stoppers) - 1; i >= 0; i-- {
+		g.stoppers[i]()
+	}
+	g.stoppers = nil
+	g.running = false
+
+	if g.storage == nil {
+		return
+	}
+	if err := g.storage.Flush(); err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Error("Failed to flush storage.")
+	}
+	if err := g.storage.Close(); err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Error("Failed to close storage.")
+	}
+	g.storage = nil
+}
+
+// BlockChain returns block chain reference.
+func (g *Gamc) BlockChain() *core.BlockChain {
+	return g.blockChain
+}
+
+// StorageMaintainer returns storage maintainer reference.
+func (g *Gamc) StorageMaintainer() *cdb.Maintainer {
+	return g.storageMaintainer
+}
+
+// NetService returns net service reference.
+func (g *Gamc) NetService() network.Service {
+	return g.netService
+}
+
+// AccountManager returns account manager reference.
+func (g *Gamc) AccountManager() core.AccountManager {
+	return g.accountManager
+}
+
+// Consensus returns consensus reference.
+func (g *Gamc) Consensus() core.Consensus {
+	return g.consensus
+}
+
+// Config returns gamc configuration.
+func (g *Gamc) Config() *config.Config {
+	return g.config
+}
+
+// Storage returns storage reference.
+func (g *Gamc) Storage() cdb.Storage {
+	return g.storage
+}
@@ -87,6 +87,9 @@ func Start(config *config.Config) {
 	go (func() {
 		cfg := GetStatsConfig(config)
 		chaincfg := conf.GetChainConfig(config)
+		if cfg.MetricsTags == nil {
+			cfg.MetricsTags = make(map[string]string)
+		}
 		cfg.MetricsTags[chainID] = fmt.Sprintf("%d", chaincfg.ChainId)
 		go collectSystemMetrics()
 		InfluxDBWithTags(metrics.DefaultRegistry, interval, cfg.Influxdb.Host, cfg.Influxdb.Db, cfg.Influxdb.User, cfg.Influxdb.Password, cfg.MetricsTags)
@@ -170,6 +173,14 @@ func NewGauge(name string) metrics.Gauge {
 	return metrics.GetOrRegisterGauge(name, metrics.DefaultRegistry)
 }
 
+// NewGaugeFloat64 create a new metrics GaugeFloat64
+func NewGaugeFloat64(name string) metrics.GaugeFloat64 {
+	if !enable {
+		return new(metrics.NilGaugeFloat64)
+	}
+	return metrics.GetOrRegisterGaugeFloat64(name, metrics.DefaultRegistry)
+}
+
 // NewHistogramWithUniformSample create a new metrics History with Uniform Sample algorithm.
 func NewHistogramWithUniformSample(name string, reservoirSize int) metrics.Histogram {
 	if !enable {
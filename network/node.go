@@ -282,6 +282,21 @@ func (node *Node) SetgamcService(ns *gamcService) {
 	node.netService = ns
 }
 
+// Config return node config.
+func (node *Node) Config() *Config {
+	return node.config
+}
+
+// StreamManager return node streamManager.
+func (node *Node) StreamManager() *StreamManager {
+	return node.streamManager
+}
+
+// RouteTable return node routeTable.
+func (node *Node) RouteTable() *RouteTable {
+	return node.routeTable
+}
+
 // ID return node ID.
 func (node *Node) ID() string {
 	return node.id.Pretty()
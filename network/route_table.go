@@ -139,7 +139,7 @@ func (table *RouteTable) GetRandomPeers(pid peer.ID) []peer.AddrInfo {
 func (table *RouteTable) AddPeerInfo(prettyID string, addrStr []string) error {
 	pid, err := peer.IDB58Decode(prettyID)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	addrs := make([]ma.Multiaddr, len(addrStr))
@@ -210,6 +210,11 @@ func (table *RouteTable) syncLoop() {
 	}
 }
 
+// PeersCount return the count of peers in route table.
+func (table *RouteTable) PeersCount() int {
+	return table.routeTable.Size()
+}
+
 // LoadSeedNodes load seed nodes.
 func (table *RouteTable) LoadSeedNodes() {
 	for _, ipfsAddr := range table.seedNodes {
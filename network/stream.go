@@ -703,6 +703,24 @@ func ByteSliceEqualBCE(a, b []byte) bool {
 	return true
 }
 
+// PeerID return the pretty id of the remote peer
+func (s *Stream) PeerID() string {
+	return s.pid.Pretty()
+}
+
+// Address return the address of the remote peer
+func (s *Stream) Address() string {
+	if s.addr == nil {
+		return ""
+	}
+	return s.addr.String()
+}
+
+// ConnectedAt return the time when the stream was connected
+func (s *Stream) ConnectedAt() int64 {
+	return s.connectedAt
+}
+
 // IsHandshakeSucceed return if the handshake in the stream succeed
 func (s *Stream) IsHandshakeSucceed() bool {
 	return s.status == streamStatusHandshakeSucceed
@@ -150,6 +150,19 @@ func (sm *StreamManager) FindByPeerID(peerID string) *Stream {
 	return v.(*Stream)
 }
 
+// ActivePeers return the streams which have finished the handshake
+func (sm *StreamManager) ActivePeers() []*Stream {
+	streams := make([]*Stream, 0)
+	sm.allStreams.Range(func(key, value interface{}) bool {
+		stream := value.(*Stream)
+		if stream.IsHandshakeSucceed() {
+			streams = append(streams, stream)
+		}
+		return true
+	})
+	return streams
+}
+
 // Start stream manager service
 func (sm *StreamManager) Start() {
 	logging.CLog().Info("Starting gamcService StreamManager...")
@@ -0,0 +1,300 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"gamc.pro/gamcio/go-gamc/core"
+	"gamc.pro/gamcio/go-gamc/network"
+	rpcpb "gamc.pro/gamcio/go-gamc/rpc/pb"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"context"
+	"errors"
+	"time"
+)
+
+// Mining actions of SetMining rpc.
+const (
+	MiningActionEnable  = "enable"
+	MiningActionDisable = "disable"
+	MiningActionSuspend = "suspend"
+	MiningActionResume  = "resume"
+)
+
+// Errors
+var (
+	ErrInvalidMiningAction  = errors.New("invalid mining action, should be one of enable, disable, suspend and resume")
+	ErrConsensusNotReady    = errors.New("consensus is not ready")
+	ErrPeerNotFound         = errors.New("peer not found")
+	ErrDisconnectedByAdmin  = errors.New("disconnected by admin")
+	ErrAccountManagerNotSet = errors.New("account manager is not set")
+)
+
+// AdminService implements the RPC admin service interface.
+type AdminService struct {
+	server *Server
+}
+
+func (s *AdminService) accountManager() (core.AccountManager, error) {
+	am := s.server.gamc.AccountManager()
+	if am == nil {
+		return nil, ErrAccountManagerNotSet
+	}
+	return am, nil
+}
+
+// Accounts is the RPC API handler.
+func (s *AdminService) Accounts(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.AccountsResponse, error) {
+	am, err := s.accountManager()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &rpcpb.AccountsResponse{}
+	for _, addr := range am.GetAllAddress() {
+		resp.Addresses = append(resp.Addresses, addr.String())
+	}
+	return resp, nil
+}
+
+// NewAccount is the RPC API handler.
+func (s *AdminService) NewAccount(ctx context.Context, req *rpcpb.NewAccountRequest) (*rpcpb.NewAccountResponse, error) {
+	am, err := s.accountManager()
+	if err != nil {
+		return nil, err
+	}
+
+	addr, mnemonic, err := am.NewAccount([]byte(req.Passphrase))
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.NewAccountResponse{Address: addr.String(), Mnemonic: mnemonic}, nil
+}
+
+// UnlockAccount is the RPC API handler.
+func (s *AdminService) UnlockAccount(ctx context.Context, req *rpcpb.UnlockAccountRequest) (*rpcpb.UnlockAccountResponse, error) {
+	am, err := s.accountManager()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := time.Duration(req.Duration) * time.Second
+	if err := am.UnLock(addr, []byte(req.Passphrase), duration); err != nil {
+		return nil, err
+	}
+	return &rpcpb.UnlockAccountResponse{Result: true}, nil
+}
+
+// LockAccount is the RPC API handler.
+func (s *AdminService) LockAccount(ctx context.Context, req *rpcpb.LockAccountRequest) (*rpcpb.LockAccountResponse, error) {
+	am, err := s.accountManager()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := am.Lock(addr); err != nil {
+		return nil, err
+	}
+	return &rpcpb.LockAccountResponse{Result: true}, nil
+}
+
+// ImportAccount is the RPC API handler.
+func (s *AdminService) ImportAccount(ctx context.Context, req *rpcpb.ImportAccountRequest) (*rpcpb.ImportAccountResponse, error) {
+	am, err := s.accountManager()
+	if err != nil {
+		return nil, err
+	}
+
+	var addr *core.Address
+	if len(req.Key) > 0 {
+		addr, err = am.Import(req.Key, []byte(req.Passphrase))
+	} else {
+		addr, err = am.ImportAccount(req.PrivateKey, []byte(req.Passphrase))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.ImportAccountResponse{Address: addr.String()}, nil
+}
+
+// ExportAccount is the RPC API handler.
+func (s *AdminService) ExportAccount(ctx context.Context, req *rpcpb.ExportAccountRequest) (*rpcpb.ExportAccountResponse, error) {
+	am, err := s.accountManager()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := am.Export(addr, []byte(req.Passphrase))
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.ExportAccountResponse{Key: key}, nil
+}
+
+// SignHash is the RPC API handler.
+func (s *AdminService) SignHash(ctx context.Context, req *rpcpb.SignHashRequest) (*rpcpb.SignHashResponse, error) {
+	am, err := s.accountManager()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := am.Sign(addr, req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.SignHashResponse{Data: data}, nil
+}
+
+// GetPeers is the RPC API handler.
+func (s *AdminService) GetPeers(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.PeersResponse, error) {
+	node, err := s.node()
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.PeersResponse{Peers: toPeersInfo(node.StreamManager().ActivePeers())}, nil
+}
+
+// AddPeer is the RPC API handler.
+func (s *AdminService) AddPeer(ctx context.Context, req *rpcpb.AddPeerRequest) (*rpcpb.AddPeerResponse, error) {
+	node, err := s.node()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := node.RouteTable().AddPeerInfo(req.Id, req.Addrs); err != nil {
+		return nil, err
+	}
+	return &rpcpb.AddPeerResponse{Result: true}, nil
+}
+
+// DisconnectPeer is the RPC API handler.
+func (s *AdminService) DisconnectPeer(ctx context.Context, req *rpcpb.DisconnectPeerRequest) (*rpcpb.DisconnectPeerResponse, error) {
+	node, err := s.node()
+	if err != nil {
+		return nil, err
+	}
+
+	if node.StreamManager().FindByPeerID(req.Id) == nil {
+		return nil, ErrPeerNotFound
+	}
+	s.server.gamc.NetService().ClosePeer(req.Id, ErrDisconnectedByAdmin)
+	return &rpcpb.DisconnectPeerResponse{Result: true}, nil
+}
+
+func (s *AdminService) node() (*network.Node, error) {
+	ns := s.server.gamc.NetService()
+	if ns == nil || ns.Node() == nil {
+		return nil, ErrNetServiceNotReady
+	}
+	return ns.Node(), nil
+}
+
+// SetMining is the RPC API handler.
+func (s *AdminService) SetMining(ctx context.Context, req *rpcpb.SetMiningRequest) (*rpcpb.MiningStateResponse, error) {
+	consensus := s.server.gamc.BlockChain().Consensus()
+	if consensus == nil {
+		return nil, ErrConsensusNotReady
+	}
+
+	switch req.Action {
+	case MiningActionEnable:
+		consensus.EnableMining()
+	case MiningActionDisable:
+		consensus.DisableMining()
+	case MiningActionSuspend:
+		consensus.SuspendMining()
+	case MiningActionResume:
+		consensus.ResumeMining()
+	default:
+		return nil, ErrInvalidMiningAction
+	}
+	return &rpcpb.MiningStateResponse{Enable: consensus.IsEnable(), Suspend: consensus.IsSuspend()}, nil
+}
+
+// GetMiningState is the RPC API handler.
+func (s *AdminService) GetMiningState(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.MiningStateResponse, error) {
+	consensus := s.server.gamc.BlockChain().Consensus()
+	if consensus == nil {
+		return nil, ErrConsensusNotReady
+	}
+	return &rpcpb.MiningStateResponse{Enable: consensus.IsEnable(), Suspend: consensus.IsSuspend()}, nil
+}
+
+// StartActiveSync is the RPC API handler.
+func (s *AdminService) StartActiveSync(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.StartActiveSyncResponse, error) {
+	if s.server.gamc.BlockChain().Consensus() == nil {
+		return nil, ErrConsensusNotReady
+	}
+	return &rpcpb.StartActiveSyncResponse{Result: s.server.gamc.BlockChain().StartActiveSync()}, nil
+}
+
+// SetHead is the RPC API handler.
+func (s *AdminService) SetHead(ctx context.Context, req *rpcpb.SetHeadRequest) (*rpcpb.SetHeadResponse, error) {
+	chain := s.server.gamc.BlockChain()
+	reverted, err := chain.SetHead(req.Height, req.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := 0
+	for _, block := range reverted {
+		txs += len(block.Transactions())
+	}
+	return &rpcpb.SetHeadResponse{
+		Tail:                 toBlockResponse(chain.TailBlock(), false),
+		RevertedBlocks:       uint64(len(reverted)),
+		ReturnedTransactions: uint64(txs),
+	}, nil
+}
+
+// CompactStorage is the RPC API handler.
+func (s *AdminService) CompactStorage(ctx context.Context, req *rpcpb.CompactStorageRequest) (*rpcpb.CompactStorageResponse, error) {
+	start, err := byteutils.FromHex(req.Start)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	limit, err := byteutils.FromHex(req.Limit)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	if len(limit) == 0 {
+		limit = nil
+	}
+
+	cost, err := s.server.gamc.StorageMaintainer().Compact(start, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcpb.CompactStorageResponse{Cost: uint64(cost / time.Millisecond)}, nil
+}
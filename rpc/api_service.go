@@ -0,0 +1,254 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"gamc.pro/gamcio/go-gamc/core"
+	corepb "gamc.pro/gamcio/go-gamc/core/pb"
+	"gamc.pro/gamcio/go-gamc/network"
+	rpcpb "gamc.pro/gamcio/go-gamc/rpc/pb"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"context"
+	"github.com/gogo/protobuf/proto"
+	"time"
+)
+
+// Transaction status
+const (
+	TxStatusPending = 0
+	TxStatusPacked  = 1
+)
+
+// APIService implements the RPC API interface.
+type APIService struct {
+	server *Server
+}
+
+// GetChainState is the RPC API handler.
+func (s *APIService) GetChainState(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.ChainStateResponse, error) {
+	chain := s.server.gamc.BlockChain()
+	tail := chain.TailBlock()
+	fixed := chain.FixedBlock()
+
+	resp := &rpcpb.ChainStateResponse{
+		ChainId:       chain.ChainId(),
+		Tail:          tail.Hash().String(),
+		Height:        tail.Height(),
+		Genesis:       chain.GenesisBlock().Hash().String(),
+		Timestamp:     time.Now().Unix(),
+		Synchronizing: chain.IsActiveSyncing(),
+		Version:       network.ClientVersion,
+	}
+	if fixed != nil {
+		resp.Fixed = fixed.Hash().String()
+		resp.FixedHeight = fixed.Height()
+	}
+	return resp, nil
+}
+
+// GetBlockByHash is the RPC API handler.
+func (s *APIService) GetBlockByHash(ctx context.Context, req *rpcpb.GetBlockByHashRequest) (*rpcpb.BlockResponse, error) {
+	hash, err := byteutils.FromHex(req.Hash)
+	if err != nil {
+		return nil, ErrInvalidHash
+	}
+
+	block := s.server.gamc.BlockChain().GetBlock(hash)
+	if block == nil {
+		return nil, ErrBlockNotFound
+	}
+	return toBlockResponse(block, req.FullFillTransaction), nil
+}
+
+// GetBlockByHeight is the RPC API handler.
+func (s *APIService) GetBlockByHeight(ctx context.Context, req *rpcpb.GetBlockByHeightRequest) (*rpcpb.BlockResponse, error) {
+	block := s.server.gamc.BlockChain().GetBlockOnCanonicalChainByHeight(req.Height)
+	if block == nil {
+		return nil, ErrBlockNotFound
+	}
+	return toBlockResponse(block, req.FullFillTransaction), nil
+}
+
+// GetAccountState is the RPC API handler.
+func (s *APIService) GetAccountState(ctx context.Context, req *rpcpb.GetAccountStateRequest) (*rpcpb.AccountStateResponse, error) {
+	addr, err := core.AddressParse(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := s.server.gamc.BlockChain()
+	block := chain.TailBlock()
+	if req.Height > 0 {
+		block = chain.GetBlockOnCanonicalChainByHeight(req.Height)
+		if block == nil {
+			return nil, ErrBlockNotFound
+		}
+	}
+
+	account, err := block.GetAccount(addr.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpcpb.AccountStateResponse{
+		Balance:     account.Balance().String(),
+		FrozenFund:  account.FrozenFund().String(),
+		PledgeFund:  account.PledgeFund().String(),
+		Nonce:       account.Nonce(),
+		CreditIndex: account.CreditIndex().String(),
+	}, nil
+}
+
+// SendRawTransaction is the RPC API handler.
+func (s *APIService) SendRawTransaction(ctx context.Context, req *rpcpb.SendRawTransactionRequest) (*rpcpb.SendTransactionResponse, error) {
+	pbTx := new(corepb.Transaction)
+	if err := proto.Unmarshal(req.Data, pbTx); err != nil {
+		return nil, err
+	}
+	tx := new(core.Transaction)
+	if err := tx.FromProto(pbTx); err != nil {
+		return nil, err
+	}
+
+	chain := s.server.gamc.BlockChain()
+	if err := tx.VerifyIntegrity(chain.ChainId()); err != nil {
+		return nil, err
+	}
+	if err := chain.TxPool().AddAndBroadcast(tx); err != nil {
+		return nil, err
+	}
+
+	return &rpcpb.SendTransactionResponse{Txhash: tx.Hash().String()}, nil
+}
+
+// GetTransaction is the RPC API handler.
+func (s *APIService) GetTransaction(ctx context.Context, req *rpcpb.GetTransactionRequest) (*rpcpb.TransactionResponse, error) {
+	hash, err := byteutils.FromHex(req.Hash)
+	if err != nil {
+		return nil, ErrInvalidHash
+	}
+
+	chain := s.server.gamc.BlockChain()
+	if tx, block := chain.GetTransaction(hash); tx != nil {
+		resp := toTransactionResponse(tx)
+		resp.BlockHash = block.Hash().String()
+		resp.BlockHeight = block.Height()
+		resp.Status = TxStatusPacked
+		return resp, nil
+	}
+	if tx := chain.TxPool().GetTransaction(hash); tx != nil {
+		resp := toTransactionResponse(tx)
+		resp.Status = TxStatusPending
+		return resp, nil
+	}
+	return nil, ErrTransactionNotFound
+}
+
+// GetNodeInfo is the RPC API handler.
+func (s *APIService) GetNodeInfo(ctx context.Context, req *rpcpb.NonParamsRequest) (*rpcpb.NodeInfoResponse, error) {
+	ns := s.server.gamc.NetService()
+	if ns == nil || ns.Node() == nil {
+		return nil, ErrNetServiceNotReady
+	}
+	node := ns.Node()
+
+	resp := &rpcpb.NodeInfoResponse{
+		Id:      node.ID(),
+		ChainId: s.server.gamc.BlockChain().ChainId(),
+		Version: network.ClientVersion,
+	}
+	if cfg := node.Config(); cfg != nil {
+		resp.Listen = cfg.Listen
+	}
+	if table := node.RouteTable(); table != nil {
+		resp.RouteTableSize = int32(table.PeersCount())
+	}
+	if sm := node.StreamManager(); sm != nil {
+		resp.Peers = toPeersInfo(sm.ActivePeers())
+	}
+	return resp, nil
+}
+
+func toPeersInfo(streams []*network.Stream) []*rpcpb.PeerInfo {
+	peers := make([]*rpcpb.PeerInfo, 0, len(streams))
+	for _, stream := range streams {
+		peers = append(peers, &rpcpb.PeerInfo{
+			Id:          stream.PeerID(),
+			Address:     stream.Address(),
+			ConnectedAt: stream.ConnectedAt(),
+		})
+	}
+	return peers
+}
+
+func toBlockResponse(block *core.Block, fullFillTransaction bool) *rpcpb.BlockResponse {
+	resp := &rpcpb.BlockResponse{
+		Hash:       block.Hash().String(),
+		ParentHash: block.ParentHash().String(),
+		Height:     block.Height(),
+		Timestamp:  block.Timestamp(),
+		ChainId:    block.Header().ChainId(),
+		StateRoot:  block.StateRoot().String(),
+		TxsRoot:    block.TxsRoot().String(),
+	}
+	if coinbase := block.Coinbase(); coinbase != nil {
+		resp.Coinbase = coinbase.String()
+	}
+	if sign := block.Signature(); sign != nil {
+		if signer, err := core.NewAddressFromPublicKey(sign.Signer); err == nil {
+			resp.Signer = signer.String()
+		}
+	}
+
+	for _, tx := range block.Transactions() {
+		if fullFillTransaction {
+			txResp := toTransactionResponse(tx)
+			txResp.BlockHash = resp.Hash
+			txResp.BlockHeight = resp.Height
+			txResp.Status = TxStatusPacked
+			resp.Transactions = append(resp.Transactions, txResp)
+		} else {
+			resp.TransactionHashes = append(resp.TransactionHashes, tx.Hash().String())
+		}
+	}
+	return resp
+}
+
+func toTransactionResponse(tx *core.Transaction) *rpcpb.TransactionResponse {
+	resp := &rpcpb.TransactionResponse{
+		Hash:      tx.Hash().String(),
+		ChainId:   tx.ChainId(),
+		Value:     tx.Value().String(),
+		Nonce:     tx.Nonce(),
+		Fee:       tx.Fee().String(),
+		Timestamp: tx.Timestamp(),
+		Priority:  tx.Priority(),
+	}
+	if from := tx.From(); from != nil {
+		resp.From = from.String()
+	}
+	if to := tx.To(); to != nil {
+		resp.To = to.String()
+	}
+	if data := tx.Data(); data != nil {
+		resp.Type = data.Type
+		resp.Data = data.Msg
+	}
+	return resp
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"gamc.pro/gamcio/go-gamc/util/config"
+)
+
+// Module names which can be enabled in http_module.
+const (
+	// APIModule the public api module
+	APIModule = "api"
+	// AdminModule the admin module
+	AdminModule = "admin"
+)
+
+// Default rpc configuration
+const (
+	DefaultHttpLimits = 128
+)
+
+// Default rpc listen addresses
+var (
+	DefaultRpcListen  = []string{"127.0.0.1:8518"}
+	DefaultHttpListen = []string{"127.0.0.1:8519"}
+)
+
+type RpcConfig struct {
+	RpcListen  []string `yaml:"rpc_listen"`
+	HttpListen []string `yaml:"http_listen"`
+	// HttpModule the modules served by the http gateway
+	HttpModule []string `yaml:"http_module"`
+	HttpCors   []string `yaml:"http_cors"`
+	// HttpLimits the concurrent requests served by the http gateway, and by the gRPC server
+	HttpLimits int    `yaml:"http_limits"`
+	IpcPath    string `yaml:"ipc_path"`
+}
+
+func GetRpcConfig(conf *config.Config) *RpcConfig {
+	rpccfg := new(RpcConfig)
+	conf.GetObject("rpc", rpccfg)
+
+	if len(rpccfg.RpcListen) == 0 {
+		rpccfg.RpcListen = DefaultRpcListen
+	}
+	if len(rpccfg.HttpListen) == 0 {
+		rpccfg.HttpListen = DefaultHttpListen
+	}
+	if rpccfg.HttpLimits <= 0 {
+		rpccfg.HttpLimits = DefaultHttpLimits
+	}
+	return rpccfg
+}
+
+// moduleEnabled return if the module is enabled in http_module.
+func (cfg *RpcConfig) moduleEnabled(module string) bool {
+	for _, v := range cfg.HttpModule {
+		if v == module {
+			return true
+		}
+	}
+	return false
+}
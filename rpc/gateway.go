@@ -0,0 +1,167 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	rpcpb "gamc.pro/gamcio/go-gamc/rpc/pb"
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/rs/cors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	adminHttpPrefix = "/v1/admin/"
+)
+
+// startGateway start the http gateway which proxies the enabled modules to the gRPC server.
+func (s *Server) startGateway() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	newMux := func() *runtime.ServeMux {
+		return runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}))
+	}
+	mux := newMux()
+	var adminMux *runtime.ServeMux
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	endpoint := s.config.RpcListen[0]
+
+	for _, module := range s.config.HttpModule {
+		if module != APIModule && module != AdminModule {
+			logging.CLog().WithFields(logrus.Fields{
+				"module": module,
+			}).Warn("Unknown http module.")
+		}
+	}
+	if s.config.moduleEnabled(APIModule) {
+		if err := rpcpb.RegisterApiServiceHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"module": APIModule,
+				"err":    err,
+			}).Error("Failed to register http module.")
+			cancel()
+			return err
+		}
+	}
+	if s.config.moduleEnabled(AdminModule) {
+		// served in process on its own mux, so that the local only check is done
+		// on the http request and the admin routes are never exposed to CORS.
+		adminMux = newMux()
+		if err := rpcpb.RegisterAdminServiceHandlerServer(ctx, adminMux, s.admin); err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"module": AdminModule,
+				"err":    err,
+			}).Error("Failed to register http module.")
+			cancel()
+			return err
+		}
+	}
+
+	var handler http.Handler = mux
+	if len(s.config.HttpCors) > 0 {
+		handler = cors.New(cors.Options{
+			AllowedOrigins: s.config.HttpCors,
+			AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+			AllowedHeaders: []string{"*"},
+		}).Handler(handler)
+	}
+	handler = adminRouteHandler(handler, adminMux)
+	handler = limitHandler(handler, s.config.HttpLimits)
+
+	for _, addr := range s.config.HttpListen {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			logging.CLog().WithFields(logrus.Fields{
+				"listen": addr,
+				"err":    err,
+			}).Error("Failed to listen http address.")
+			cancel()
+			return err
+		}
+
+		srv := &http.Server{Addr: addr, Handler: handler}
+		s.httpServers = append(s.httpServers, srv)
+		go func() {
+			if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+				logging.CLog().WithFields(logrus.Fields{
+					"listen": srv.Addr,
+					"err":    err,
+				}).Error("HTTP gateway stopped serving.")
+			}
+		}()
+	}
+	return nil
+}
+
+// adminRouteHandler routes the admin requests to admin, outside of the CORS handler,
+// the admin requests are rejected if the admin module is not enabled.
+func adminRouteHandler(h http.Handler, admin http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(path.Clean(r.URL.Path)+"/", adminHttpPrefix) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if admin == nil {
+			http.NotFound(w, r)
+			return
+		}
+		adminLocalHandler(admin).ServeHTTP(w, r)
+	})
+}
+
+// adminLocalHandler rejects the admin requests which are not from localhost, or which
+// are sent by a browser, as any web page can make the browser send requests from localhost.
+func adminLocalHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			http.Error(w, ErrAdminNotLocal.Error(), http.StatusForbidden)
+			return
+		}
+		if len(r.Header.Get("Origin")) > 0 {
+			http.Error(w, ErrAdminCrossOrigin.Error(), http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// limitHandler rejects the requests exceeding the limit of concurrent requests.
+func limitHandler(h http.Handler, limits int) http.Handler {
+	sem := make(chan struct{}, limits)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case sem <- struct{}{}:
+			defer func() { <-sem }()
+			h.ServeHTTP(w, r)
+		default:
+			http.Error(w, ErrHttpLimitsExceeded.Error(), http.StatusServiceUnavailable)
+		}
+	})
+}
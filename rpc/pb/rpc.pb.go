@@ -0,0 +1,3323 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: rpc.proto
+
+package rpcpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// Request message of non params.
+type NonParamsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NonParamsRequest) Reset()         { *m = NonParamsRequest{} }
+func (m *NonParamsRequest) String() string { return proto.CompactTextString(m) }
+func (*NonParamsRequest) ProtoMessage()    {}
+func (*NonParamsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{0}
+}
+func (m *NonParamsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NonParamsRequest.Unmarshal(m, b)
+}
+func (m *NonParamsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NonParamsRequest.Marshal(b, m, deterministic)
+}
+func (m *NonParamsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NonParamsRequest.Merge(m, src)
+}
+func (m *NonParamsRequest) XXX_Size() int {
+	return xxx_messageInfo_NonParamsRequest.Size(m)
+}
+func (m *NonParamsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NonParamsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NonParamsRequest proto.InternalMessageInfo
+
+// Response message of GetChainState rpc.
+type ChainStateResponse struct {
+	// Block chain id
+	ChainId uint32 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Current tail block hash
+	Tail string `protobuf:"bytes,2,opt,name=tail,proto3" json:"tail,omitempty"`
+	// Current tail block height
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// Current fixed block hash
+	Fixed string `protobuf:"bytes,4,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	// Current fixed block height
+	FixedHeight uint64 `protobuf:"varint,5,opt,name=fixed_height,json=fixedHeight,proto3" json:"fixed_height,omitempty"`
+	// Genesis block hash
+	Genesis string `protobuf:"bytes,6,opt,name=genesis,proto3" json:"genesis,omitempty"`
+	// Current timestamp
+	Timestamp int64 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Whether the node is syncing blocks from peers
+	Synchronizing bool `protobuf:"varint,8,opt,name=synchronizing,proto3" json:"synchronizing,omitempty"`
+	// The client version of the node
+	Version              string   `protobuf:"bytes,9,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChainStateResponse) Reset()         { *m = ChainStateResponse{} }
+func (m *ChainStateResponse) String() string { return proto.CompactTextString(m) }
+func (*ChainStateResponse) ProtoMessage()    {}
+func (*ChainStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{1}
+}
+func (m *ChainStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChainStateResponse.Unmarshal(m, b)
+}
+func (m *ChainStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChainStateResponse.Marshal(b, m, deterministic)
+}
+func (m *ChainStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainStateResponse.Merge(m, src)
+}
+func (m *ChainStateResponse) XXX_Size() int {
+	return xxx_messageInfo_ChainStateResponse.Size(m)
+}
+func (m *ChainStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChainStateResponse proto.InternalMessageInfo
+
+func (m *ChainStateResponse) GetChainId() uint32 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *ChainStateResponse) GetTail() string {
+	if m != nil {
+		return m.Tail
+	}
+	return ""
+}
+
+func (m *ChainStateResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ChainStateResponse) GetFixed() string {
+	if m != nil {
+		return m.Fixed
+	}
+	return ""
+}
+
+func (m *ChainStateResponse) GetFixedHeight() uint64 {
+	if m != nil {
+		return m.FixedHeight
+	}
+	return 0
+}
+
+func (m *ChainStateResponse) GetGenesis() string {
+	if m != nil {
+		return m.Genesis
+	}
+	return ""
+}
+
+func (m *ChainStateResponse) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ChainStateResponse) GetSynchronizing() bool {
+	if m != nil {
+		return m.Synchronizing
+	}
+	return false
+}
+
+func (m *ChainStateResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+// Request message of GetBlockByHash rpc.
+type GetBlockByHashRequest struct {
+	// Hex string of block hash.
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// If true it returns the full transaction objects, if false only the hashes of the transactions.
+	FullFillTransaction  bool     `protobuf:"varint,2,opt,name=full_fill_transaction,json=fullFillTransaction,proto3" json:"full_fill_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockByHashRequest) Reset()         { *m = GetBlockByHashRequest{} }
+func (m *GetBlockByHashRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockByHashRequest) ProtoMessage()    {}
+func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{2}
+}
+func (m *GetBlockByHashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockByHashRequest.Unmarshal(m, b)
+}
+func (m *GetBlockByHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockByHashRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockByHashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockByHashRequest.Merge(m, src)
+}
+func (m *GetBlockByHashRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockByHashRequest.Size(m)
+}
+func (m *GetBlockByHashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockByHashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockByHashRequest proto.InternalMessageInfo
+
+func (m *GetBlockByHashRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *GetBlockByHashRequest) GetFullFillTransaction() bool {
+	if m != nil {
+		return m.FullFillTransaction
+	}
+	return false
+}
+
+// Request message of GetBlockByHeight rpc.
+type GetBlockByHeightRequest struct {
+	// Block height.
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// If true it returns the full transaction objects, if false only the hashes of the transactions.
+	FullFillTransaction  bool     `protobuf:"varint,2,opt,name=full_fill_transaction,json=fullFillTransaction,proto3" json:"full_fill_transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockByHeightRequest) Reset()         { *m = GetBlockByHeightRequest{} }
+func (m *GetBlockByHeightRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockByHeightRequest) ProtoMessage()    {}
+func (*GetBlockByHeightRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{3}
+}
+func (m *GetBlockByHeightRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockByHeightRequest.Unmarshal(m, b)
+}
+func (m *GetBlockByHeightRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockByHeightRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockByHeightRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockByHeightRequest.Merge(m, src)
+}
+func (m *GetBlockByHeightRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockByHeightRequest.Size(m)
+}
+func (m *GetBlockByHeightRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockByHeightRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockByHeightRequest proto.InternalMessageInfo
+
+func (m *GetBlockByHeightRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetBlockByHeightRequest) GetFullFillTransaction() bool {
+	if m != nil {
+		return m.FullFillTransaction
+	}
+	return false
+}
+
+// Response message of block.
+type BlockResponse struct {
+	// Hex string of block hash.
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Hex string of block parent hash.
+	ParentHash string `protobuf:"bytes,2,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	// Block height.
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// Block timestamp.
+	Timestamp int64 `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Block chain id.
+	ChainId uint32 `protobuf:"varint,5,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Block coinbase address.
+	Coinbase string `protobuf:"bytes,6,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	// Hex string of block state root.
+	StateRoot string `protobuf:"bytes,7,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	// Hex string of block txs root.
+	TxsRoot string `protobuf:"bytes,8,opt,name=txs_root,json=txsRoot,proto3" json:"txs_root,omitempty"`
+	// Hex string of the block signer.
+	Signer string `protobuf:"bytes,9,opt,name=signer,proto3" json:"signer,omitempty"`
+	// Transaction hashes, filled if full_fill_transaction is false.
+	TransactionHashes []string `protobuf:"bytes,10,rep,name=transaction_hashes,json=transactionHashes,proto3" json:"transaction_hashes,omitempty"`
+	// Transactions, filled if full_fill_transaction is true.
+	Transactions         []*TransactionResponse `protobuf:"bytes,11,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *BlockResponse) Reset()         { *m = BlockResponse{} }
+func (m *BlockResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockResponse) ProtoMessage()    {}
+func (*BlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{4}
+}
+func (m *BlockResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockResponse.Unmarshal(m, b)
+}
+func (m *BlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockResponse.Marshal(b, m, deterministic)
+}
+func (m *BlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockResponse.Merge(m, src)
+}
+func (m *BlockResponse) XXX_Size() int {
+	return xxx_messageInfo_BlockResponse.Size(m)
+}
+func (m *BlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockResponse proto.InternalMessageInfo
+
+func (m *BlockResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *BlockResponse) GetParentHash() string {
+	if m != nil {
+		return m.ParentHash
+	}
+	return ""
+}
+
+func (m *BlockResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockResponse) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *BlockResponse) GetChainId() uint32 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *BlockResponse) GetCoinbase() string {
+	if m != nil {
+		return m.Coinbase
+	}
+	return ""
+}
+
+func (m *BlockResponse) GetStateRoot() string {
+	if m != nil {
+		return m.StateRoot
+	}
+	return ""
+}
+
+func (m *BlockResponse) GetTxsRoot() string {
+	if m != nil {
+		return m.TxsRoot
+	}
+	return ""
+}
+
+func (m *BlockResponse) GetSigner() string {
+	if m != nil {
+		return m.Signer
+	}
+	return ""
+}
+
+func (m *BlockResponse) GetTransactionHashes() []string {
+	if m != nil {
+		return m.TransactionHashes
+	}
+	return nil
+}
+
+func (m *BlockResponse) GetTransactions() []*TransactionResponse {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+// Request message of GetAccountState rpc.
+type GetAccountStateRequest struct {
+	// Base58 string of the account address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Block height, 0 means the tail block.
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetAccountStateRequest) Reset()         { *m = GetAccountStateRequest{} }
+func (m *GetAccountStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAccountStateRequest) ProtoMessage()    {}
+func (*GetAccountStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{5}
+}
+func (m *GetAccountStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAccountStateRequest.Unmarshal(m, b)
+}
+func (m *GetAccountStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAccountStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetAccountStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAccountStateRequest.Merge(m, src)
+}
+func (m *GetAccountStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAccountStateRequest.Size(m)
+}
+func (m *GetAccountStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAccountStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAccountStateRequest proto.InternalMessageInfo
+
+func (m *GetAccountStateRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *GetAccountStateRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// Response message of GetAccountState rpc.
+type AccountStateResponse struct {
+	// Current balance in unit 1/(10^18) gamc.
+	Balance string `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	// Current frozen fund.
+	FrozenFund string `protobuf:"bytes,2,opt,name=frozen_fund,json=frozenFund,proto3" json:"frozen_fund,omitempty"`
+	// Current pledge fund.
+	PledgeFund string `protobuf:"bytes,3,opt,name=pledge_fund,json=pledgeFund,proto3" json:"pledge_fund,omitempty"`
+	// Current transaction count.
+	Nonce uint64 `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// Current credit index.
+	CreditIndex          string   `protobuf:"bytes,5,opt,name=credit_index,json=creditIndex,proto3" json:"credit_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountStateResponse) Reset()         { *m = AccountStateResponse{} }
+func (m *AccountStateResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountStateResponse) ProtoMessage()    {}
+func (*AccountStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{6}
+}
+func (m *AccountStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountStateResponse.Unmarshal(m, b)
+}
+func (m *AccountStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountStateResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountStateResponse.Merge(m, src)
+}
+func (m *AccountStateResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountStateResponse.Size(m)
+}
+func (m *AccountStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountStateResponse proto.InternalMessageInfo
+
+func (m *AccountStateResponse) GetBalance() string {
+	if m != nil {
+		return m.Balance
+	}
+	return ""
+}
+
+func (m *AccountStateResponse) GetFrozenFund() string {
+	if m != nil {
+		return m.FrozenFund
+	}
+	return ""
+}
+
+func (m *AccountStateResponse) GetPledgeFund() string {
+	if m != nil {
+		return m.PledgeFund
+	}
+	return ""
+}
+
+func (m *AccountStateResponse) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *AccountStateResponse) GetCreditIndex() string {
+	if m != nil {
+		return m.CreditIndex
+	}
+	return ""
+}
+
+// Request message of SendRawTransaction rpc.
+type SendRawTransactionRequest struct {
+	// Signed data of transaction
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendRawTransactionRequest) Reset()         { *m = SendRawTransactionRequest{} }
+func (m *SendRawTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*SendRawTransactionRequest) ProtoMessage()    {}
+func (*SendRawTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{7}
+}
+func (m *SendRawTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendRawTransactionRequest.Unmarshal(m, b)
+}
+func (m *SendRawTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendRawTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *SendRawTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendRawTransactionRequest.Merge(m, src)
+}
+func (m *SendRawTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_SendRawTransactionRequest.Size(m)
+}
+func (m *SendRawTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendRawTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendRawTransactionRequest proto.InternalMessageInfo
+
+func (m *SendRawTransactionRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// Response message of SendRawTransaction rpc.
+type SendTransactionResponse struct {
+	// Hex string of transaction hash.
+	Txhash               string   `protobuf:"bytes,1,opt,name=txhash,proto3" json:"txhash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SendTransactionResponse) Reset()         { *m = SendTransactionResponse{} }
+func (m *SendTransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*SendTransactionResponse) ProtoMessage()    {}
+func (*SendTransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{8}
+}
+func (m *SendTransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendTransactionResponse.Unmarshal(m, b)
+}
+func (m *SendTransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendTransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *SendTransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendTransactionResponse.Merge(m, src)
+}
+func (m *SendTransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_SendTransactionResponse.Size(m)
+}
+func (m *SendTransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendTransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SendTransactionResponse proto.InternalMessageInfo
+
+func (m *SendTransactionResponse) GetTxhash() string {
+	if m != nil {
+		return m.Txhash
+	}
+	return ""
+}
+
+// Request message of GetTransaction rpc.
+type GetTransactionRequest struct {
+	// Hex string of transaction hash.
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTransactionRequest) Reset()         { *m = GetTransactionRequest{} }
+func (m *GetTransactionRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTransactionRequest) ProtoMessage()    {}
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{9}
+}
+func (m *GetTransactionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTransactionRequest.Unmarshal(m, b)
+}
+func (m *GetTransactionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTransactionRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTransactionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTransactionRequest.Merge(m, src)
+}
+func (m *GetTransactionRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTransactionRequest.Size(m)
+}
+func (m *GetTransactionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTransactionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTransactionRequest proto.InternalMessageInfo
+
+func (m *GetTransactionRequest) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+// Response message of transaction.
+type TransactionResponse struct {
+	// Hex string of tx hash.
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Transaction chain id.
+	ChainId uint32 `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Base58 string of the sender account address.
+	From string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// Base58 string of the receiver account address.
+	To string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	// Transfer amount.
+	Value string `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	// Transaction nonce.
+	Nonce uint64 `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// Transaction fee.
+	Fee string `protobuf:"bytes,7,opt,name=fee,proto3" json:"fee,omitempty"`
+	// Transaction timestamp.
+	Timestamp int64 `protobuf:"varint,8,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Transaction data type.
+	Type string `protobuf:"bytes,9,opt,name=type,proto3" json:"type,omitempty"`
+	// Transaction data payload.
+	Data []byte `protobuf:"bytes,10,opt,name=data,proto3" json:"data,omitempty"`
+	// Transaction priority.
+	Priority uint32 `protobuf:"varint,11,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Hex string of the block hash which contains the tx, empty if the tx is pending.
+	BlockHash string `protobuf:"bytes,12,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	// Height of the block which contains the tx, 0 if the tx is pending.
+	BlockHeight uint64 `protobuf:"varint,13,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	// Transaction status, 0 pending, 1 packed.
+	Status               int32    `protobuf:"varint,14,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionResponse) Reset()         { *m = TransactionResponse{} }
+func (m *TransactionResponse) String() string { return proto.CompactTextString(m) }
+func (*TransactionResponse) ProtoMessage()    {}
+func (*TransactionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{10}
+}
+func (m *TransactionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionResponse.Unmarshal(m, b)
+}
+func (m *TransactionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionResponse.Marshal(b, m, deterministic)
+}
+func (m *TransactionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionResponse.Merge(m, src)
+}
+func (m *TransactionResponse) XXX_Size() int {
+	return xxx_messageInfo_TransactionResponse.Size(m)
+}
+func (m *TransactionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionResponse proto.InternalMessageInfo
+
+func (m *TransactionResponse) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetChainId() uint32 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *TransactionResponse) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *TransactionResponse) GetFee() string {
+	if m != nil {
+		return m.Fee
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *TransactionResponse) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *TransactionResponse) GetPriority() uint32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *TransactionResponse) GetBlockHash() string {
+	if m != nil {
+		return m.BlockHash
+	}
+	return ""
+}
+
+func (m *TransactionResponse) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *TransactionResponse) GetStatus() int32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+// Response message of GetNodeInfo rpc.
+type NodeInfoResponse struct {
+	// the id of the node.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the chain id.
+	ChainId uint32 `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// the node listen addresses.
+	Listen []string `protobuf:"bytes,3,rep,name=listen,proto3" json:"listen,omitempty"`
+	// the client version of the node.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// the count of peers in the route table.
+	RouteTableSize int32 `protobuf:"varint,5,opt,name=route_table_size,json=routeTableSize,proto3" json:"route_table_size,omitempty"`
+	// the connected peers.
+	Peers                []*PeerInfo `protobuf:"bytes,6,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *NodeInfoResponse) Reset()         { *m = NodeInfoResponse{} }
+func (m *NodeInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeInfoResponse) ProtoMessage()    {}
+func (*NodeInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{11}
+}
+func (m *NodeInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NodeInfoResponse.Unmarshal(m, b)
+}
+func (m *NodeInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NodeInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *NodeInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeInfoResponse.Merge(m, src)
+}
+func (m *NodeInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_NodeInfoResponse.Size(m)
+}
+func (m *NodeInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeInfoResponse proto.InternalMessageInfo
+
+func (m *NodeInfoResponse) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *NodeInfoResponse) GetChainId() uint32 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *NodeInfoResponse) GetListen() []string {
+	if m != nil {
+		return m.Listen
+	}
+	return nil
+}
+
+func (m *NodeInfoResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *NodeInfoResponse) GetRouteTableSize() int32 {
+	if m != nil {
+		return m.RouteTableSize
+	}
+	return 0
+}
+
+func (m *NodeInfoResponse) GetPeers() []*PeerInfo {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+// Info of a connected peer.
+type PeerInfo struct {
+	// the id of the peer.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the address of the peer.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// the time when the peer was connected.
+	ConnectedAt          int64    `protobuf:"varint,3,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
+func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
+func (*PeerInfo) ProtoMessage()    {}
+func (*PeerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{12}
+}
+func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeerInfo.Unmarshal(m, b)
+}
+func (m *PeerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeerInfo.Marshal(b, m, deterministic)
+}
+func (m *PeerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerInfo.Merge(m, src)
+}
+func (m *PeerInfo) XXX_Size() int {
+	return xxx_messageInfo_PeerInfo.Size(m)
+}
+func (m *PeerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeerInfo proto.InternalMessageInfo
+
+func (m *PeerInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *PeerInfo) GetConnectedAt() int64 {
+	if m != nil {
+		return m.ConnectedAt
+	}
+	return 0
+}
+
+// Response message of Accounts rpc.
+type AccountsResponse struct {
+	// Base58 string of the account addresses.
+	Addresses            []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AccountsResponse) Reset()         { *m = AccountsResponse{} }
+func (m *AccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*AccountsResponse) ProtoMessage()    {}
+func (*AccountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{13}
+}
+func (m *AccountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AccountsResponse.Unmarshal(m, b)
+}
+func (m *AccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AccountsResponse.Marshal(b, m, deterministic)
+}
+func (m *AccountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountsResponse.Merge(m, src)
+}
+func (m *AccountsResponse) XXX_Size() int {
+	return xxx_messageInfo_AccountsResponse.Size(m)
+}
+func (m *AccountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AccountsResponse proto.InternalMessageInfo
+
+func (m *AccountsResponse) GetAddresses() []string {
+	if m != nil {
+		return m.Addresses
+	}
+	return nil
+}
+
+// Request message of NewAccount rpc.
+type NewAccountRequest struct {
+	Passphrase           string   `protobuf:"bytes,1,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewAccountRequest) Reset()         { *m = NewAccountRequest{} }
+func (m *NewAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*NewAccountRequest) ProtoMessage()    {}
+func (*NewAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{14}
+}
+func (m *NewAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewAccountRequest.Unmarshal(m, b)
+}
+func (m *NewAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *NewAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewAccountRequest.Merge(m, src)
+}
+func (m *NewAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_NewAccountRequest.Size(m)
+}
+func (m *NewAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewAccountRequest proto.InternalMessageInfo
+
+func (m *NewAccountRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+// Response message of NewAccount rpc.
+type NewAccountResponse struct {
+	// Base58 string of the new account address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Mnemonic words of the new account.
+	Mnemonic             string   `protobuf:"bytes,2,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewAccountResponse) Reset()         { *m = NewAccountResponse{} }
+func (m *NewAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*NewAccountResponse) ProtoMessage()    {}
+func (*NewAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{15}
+}
+func (m *NewAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewAccountResponse.Unmarshal(m, b)
+}
+func (m *NewAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *NewAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewAccountResponse.Merge(m, src)
+}
+func (m *NewAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_NewAccountResponse.Size(m)
+}
+func (m *NewAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewAccountResponse proto.InternalMessageInfo
+
+func (m *NewAccountResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *NewAccountResponse) GetMnemonic() string {
+	if m != nil {
+		return m.Mnemonic
+	}
+	return ""
+}
+
+// Request message of UnlockAccount rpc.
+type UnlockAccountRequest struct {
+	// Base58 string of the account address.
+	Address    string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Passphrase string `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	// Unlock duration in seconds, 0 means the default duration.
+	Duration             uint64   `protobuf:"varint,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockAccountRequest) Reset()         { *m = UnlockAccountRequest{} }
+func (m *UnlockAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*UnlockAccountRequest) ProtoMessage()    {}
+func (*UnlockAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{16}
+}
+func (m *UnlockAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockAccountRequest.Unmarshal(m, b)
+}
+func (m *UnlockAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *UnlockAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockAccountRequest.Merge(m, src)
+}
+func (m *UnlockAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_UnlockAccountRequest.Size(m)
+}
+func (m *UnlockAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockAccountRequest proto.InternalMessageInfo
+
+func (m *UnlockAccountRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *UnlockAccountRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+func (m *UnlockAccountRequest) GetDuration() uint64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+// Response message of UnlockAccount rpc.
+type UnlockAccountResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnlockAccountResponse) Reset()         { *m = UnlockAccountResponse{} }
+func (m *UnlockAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*UnlockAccountResponse) ProtoMessage()    {}
+func (*UnlockAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{17}
+}
+func (m *UnlockAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnlockAccountResponse.Unmarshal(m, b)
+}
+func (m *UnlockAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnlockAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *UnlockAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnlockAccountResponse.Merge(m, src)
+}
+func (m *UnlockAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_UnlockAccountResponse.Size(m)
+}
+func (m *UnlockAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnlockAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnlockAccountResponse proto.InternalMessageInfo
+
+func (m *UnlockAccountResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// Request message of LockAccount rpc.
+type LockAccountRequest struct {
+	// Base58 string of the account address.
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockAccountRequest) Reset()         { *m = LockAccountRequest{} }
+func (m *LockAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*LockAccountRequest) ProtoMessage()    {}
+func (*LockAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{18}
+}
+func (m *LockAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockAccountRequest.Unmarshal(m, b)
+}
+func (m *LockAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *LockAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockAccountRequest.Merge(m, src)
+}
+func (m *LockAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_LockAccountRequest.Size(m)
+}
+func (m *LockAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockAccountRequest proto.InternalMessageInfo
+
+func (m *LockAccountRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+// Response message of LockAccount rpc.
+type LockAccountResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LockAccountResponse) Reset()         { *m = LockAccountResponse{} }
+func (m *LockAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*LockAccountResponse) ProtoMessage()    {}
+func (*LockAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{19}
+}
+func (m *LockAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LockAccountResponse.Unmarshal(m, b)
+}
+func (m *LockAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LockAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *LockAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LockAccountResponse.Merge(m, src)
+}
+func (m *LockAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_LockAccountResponse.Size(m)
+}
+func (m *LockAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LockAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LockAccountResponse proto.InternalMessageInfo
+
+func (m *LockAccountResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// Request message of ImportAccount rpc.
+type ImportAccountRequest struct {
+	// Content of the key file, takes precedence over private_key.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Raw private key.
+	PrivateKey           []byte   `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	Passphrase           string   `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportAccountRequest) Reset()         { *m = ImportAccountRequest{} }
+func (m *ImportAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountRequest) ProtoMessage()    {}
+func (*ImportAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{20}
+}
+func (m *ImportAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAccountRequest.Unmarshal(m, b)
+}
+func (m *ImportAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *ImportAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAccountRequest.Merge(m, src)
+}
+func (m *ImportAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportAccountRequest.Size(m)
+}
+func (m *ImportAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAccountRequest proto.InternalMessageInfo
+
+func (m *ImportAccountRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *ImportAccountRequest) GetPrivateKey() []byte {
+	if m != nil {
+		return m.PrivateKey
+	}
+	return nil
+}
+
+func (m *ImportAccountRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+// Response message of ImportAccount rpc.
+type ImportAccountResponse struct {
+	// Base58 string of the imported account address.
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportAccountResponse) Reset()         { *m = ImportAccountResponse{} }
+func (m *ImportAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportAccountResponse) ProtoMessage()    {}
+func (*ImportAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{21}
+}
+func (m *ImportAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportAccountResponse.Unmarshal(m, b)
+}
+func (m *ImportAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *ImportAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportAccountResponse.Merge(m, src)
+}
+func (m *ImportAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportAccountResponse.Size(m)
+}
+func (m *ImportAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportAccountResponse proto.InternalMessageInfo
+
+func (m *ImportAccountResponse) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+// Request message of ExportAccount rpc.
+type ExportAccountRequest struct {
+	// Base58 string of the account address.
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Passphrase           string   `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportAccountRequest) Reset()         { *m = ExportAccountRequest{} }
+func (m *ExportAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportAccountRequest) ProtoMessage()    {}
+func (*ExportAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{22}
+}
+func (m *ExportAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportAccountRequest.Unmarshal(m, b)
+}
+func (m *ExportAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportAccountRequest.Merge(m, src)
+}
+func (m *ExportAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportAccountRequest.Size(m)
+}
+func (m *ExportAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportAccountRequest proto.InternalMessageInfo
+
+func (m *ExportAccountRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *ExportAccountRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+// Response message of ExportAccount rpc.
+type ExportAccountResponse struct {
+	// Content of the key file.
+	Key                  []byte   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportAccountResponse) Reset()         { *m = ExportAccountResponse{} }
+func (m *ExportAccountResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportAccountResponse) ProtoMessage()    {}
+func (*ExportAccountResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{23}
+}
+func (m *ExportAccountResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportAccountResponse.Unmarshal(m, b)
+}
+func (m *ExportAccountResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportAccountResponse.Marshal(b, m, deterministic)
+}
+func (m *ExportAccountResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportAccountResponse.Merge(m, src)
+}
+func (m *ExportAccountResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportAccountResponse.Size(m)
+}
+func (m *ExportAccountResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportAccountResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportAccountResponse proto.InternalMessageInfo
+
+func (m *ExportAccountResponse) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// Request message of SignHash rpc.
+type SignHashRequest struct {
+	// Base58 string of the unlocked account address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The hash to sign.
+	Hash                 []byte   `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignHashRequest) Reset()         { *m = SignHashRequest{} }
+func (m *SignHashRequest) String() string { return proto.CompactTextString(m) }
+func (*SignHashRequest) ProtoMessage()    {}
+func (*SignHashRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{24}
+}
+func (m *SignHashRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignHashRequest.Unmarshal(m, b)
+}
+func (m *SignHashRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignHashRequest.Marshal(b, m, deterministic)
+}
+func (m *SignHashRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignHashRequest.Merge(m, src)
+}
+func (m *SignHashRequest) XXX_Size() int {
+	return xxx_messageInfo_SignHashRequest.Size(m)
+}
+func (m *SignHashRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignHashRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignHashRequest proto.InternalMessageInfo
+
+func (m *SignHashRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SignHashRequest) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+// Response message of SignHash rpc.
+type SignHashResponse struct {
+	// The signature.
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignHashResponse) Reset()         { *m = SignHashResponse{} }
+func (m *SignHashResponse) String() string { return proto.CompactTextString(m) }
+func (*SignHashResponse) ProtoMessage()    {}
+func (*SignHashResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{25}
+}
+func (m *SignHashResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignHashResponse.Unmarshal(m, b)
+}
+func (m *SignHashResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignHashResponse.Marshal(b, m, deterministic)
+}
+func (m *SignHashResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignHashResponse.Merge(m, src)
+}
+func (m *SignHashResponse) XXX_Size() int {
+	return xxx_messageInfo_SignHashResponse.Size(m)
+}
+func (m *SignHashResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignHashResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignHashResponse proto.InternalMessageInfo
+
+func (m *SignHashResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// Response message of GetPeers rpc.
+type PeersResponse struct {
+	Peers                []*PeerInfo `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *PeersResponse) Reset()         { *m = PeersResponse{} }
+func (m *PeersResponse) String() string { return proto.CompactTextString(m) }
+func (*PeersResponse) ProtoMessage()    {}
+func (*PeersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{26}
+}
+func (m *PeersResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PeersResponse.Unmarshal(m, b)
+}
+func (m *PeersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PeersResponse.Marshal(b, m, deterministic)
+}
+func (m *PeersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeersResponse.Merge(m, src)
+}
+func (m *PeersResponse) XXX_Size() int {
+	return xxx_messageInfo_PeersResponse.Size(m)
+}
+func (m *PeersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PeersResponse proto.InternalMessageInfo
+
+func (m *PeersResponse) GetPeers() []*PeerInfo {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+// Request message of AddPeer rpc.
+type AddPeerRequest struct {
+	// the id of the peer.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the multiaddrs of the peer.
+	Addrs                []string `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddPeerRequest) Reset()         { *m = AddPeerRequest{} }
+func (m *AddPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*AddPeerRequest) ProtoMessage()    {}
+func (*AddPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{27}
+}
+func (m *AddPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddPeerRequest.Unmarshal(m, b)
+}
+func (m *AddPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *AddPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPeerRequest.Merge(m, src)
+}
+func (m *AddPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_AddPeerRequest.Size(m)
+}
+func (m *AddPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddPeerRequest proto.InternalMessageInfo
+
+func (m *AddPeerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AddPeerRequest) GetAddrs() []string {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+// Response message of AddPeer rpc.
+type AddPeerResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AddPeerResponse) Reset()         { *m = AddPeerResponse{} }
+func (m *AddPeerResponse) String() string { return proto.CompactTextString(m) }
+func (*AddPeerResponse) ProtoMessage()    {}
+func (*AddPeerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{28}
+}
+func (m *AddPeerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddPeerResponse.Unmarshal(m, b)
+}
+func (m *AddPeerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddPeerResponse.Marshal(b, m, deterministic)
+}
+func (m *AddPeerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPeerResponse.Merge(m, src)
+}
+func (m *AddPeerResponse) XXX_Size() int {
+	return xxx_messageInfo_AddPeerResponse.Size(m)
+}
+func (m *AddPeerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPeerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddPeerResponse proto.InternalMessageInfo
+
+func (m *AddPeerResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// Request message of DisconnectPeer rpc.
+type DisconnectPeerRequest struct {
+	// the id of the peer.
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectPeerRequest) Reset()         { *m = DisconnectPeerRequest{} }
+func (m *DisconnectPeerRequest) String() string { return proto.CompactTextString(m) }
+func (*DisconnectPeerRequest) ProtoMessage()    {}
+func (*DisconnectPeerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{29}
+}
+func (m *DisconnectPeerRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisconnectPeerRequest.Unmarshal(m, b)
+}
+func (m *DisconnectPeerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisconnectPeerRequest.Marshal(b, m, deterministic)
+}
+func (m *DisconnectPeerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectPeerRequest.Merge(m, src)
+}
+func (m *DisconnectPeerRequest) XXX_Size() int {
+	return xxx_messageInfo_DisconnectPeerRequest.Size(m)
+}
+func (m *DisconnectPeerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectPeerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectPeerRequest proto.InternalMessageInfo
+
+func (m *DisconnectPeerRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// Response message of DisconnectPeer rpc.
+type DisconnectPeerResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectPeerResponse) Reset()         { *m = DisconnectPeerResponse{} }
+func (m *DisconnectPeerResponse) String() string { return proto.CompactTextString(m) }
+func (*DisconnectPeerResponse) ProtoMessage()    {}
+func (*DisconnectPeerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{30}
+}
+func (m *DisconnectPeerResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DisconnectPeerResponse.Unmarshal(m, b)
+}
+func (m *DisconnectPeerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DisconnectPeerResponse.Marshal(b, m, deterministic)
+}
+func (m *DisconnectPeerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectPeerResponse.Merge(m, src)
+}
+func (m *DisconnectPeerResponse) XXX_Size() int {
+	return xxx_messageInfo_DisconnectPeerResponse.Size(m)
+}
+func (m *DisconnectPeerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectPeerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DisconnectPeerResponse proto.InternalMessageInfo
+
+func (m *DisconnectPeerResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// Request message of SetMining rpc.
+type SetMiningRequest struct {
+	// One of enable, disable, suspend and resume.
+	Action               string   `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetMiningRequest) Reset()         { *m = SetMiningRequest{} }
+func (m *SetMiningRequest) String() string { return proto.CompactTextString(m) }
+func (*SetMiningRequest) ProtoMessage()    {}
+func (*SetMiningRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{31}
+}
+func (m *SetMiningRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetMiningRequest.Unmarshal(m, b)
+}
+func (m *SetMiningRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetMiningRequest.Marshal(b, m, deterministic)
+}
+func (m *SetMiningRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetMiningRequest.Merge(m, src)
+}
+func (m *SetMiningRequest) XXX_Size() int {
+	return xxx_messageInfo_SetMiningRequest.Size(m)
+}
+func (m *SetMiningRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetMiningRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetMiningRequest proto.InternalMessageInfo
+
+func (m *SetMiningRequest) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+// Response message of mining state.
+type MiningStateResponse struct {
+	Enable               bool     `protobuf:"varint,1,opt,name=enable,proto3" json:"enable,omitempty"`
+	Suspend              bool     `protobuf:"varint,2,opt,name=suspend,proto3" json:"suspend,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MiningStateResponse) Reset()         { *m = MiningStateResponse{} }
+func (m *MiningStateResponse) String() string { return proto.CompactTextString(m) }
+func (*MiningStateResponse) ProtoMessage()    {}
+func (*MiningStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{32}
+}
+func (m *MiningStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MiningStateResponse.Unmarshal(m, b)
+}
+func (m *MiningStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MiningStateResponse.Marshal(b, m, deterministic)
+}
+func (m *MiningStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MiningStateResponse.Merge(m, src)
+}
+func (m *MiningStateResponse) XXX_Size() int {
+	return xxx_messageInfo_MiningStateResponse.Size(m)
+}
+func (m *MiningStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MiningStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MiningStateResponse proto.InternalMessageInfo
+
+func (m *MiningStateResponse) GetEnable() bool {
+	if m != nil {
+		return m.Enable
+	}
+	return false
+}
+
+func (m *MiningStateResponse) GetSuspend() bool {
+	if m != nil {
+		return m.Suspend
+	}
+	return false
+}
+
+// Response message of StartActiveSync rpc.
+type StartActiveSyncResponse struct {
+	Result               bool     `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartActiveSyncResponse) Reset()         { *m = StartActiveSyncResponse{} }
+func (m *StartActiveSyncResponse) String() string { return proto.CompactTextString(m) }
+func (*StartActiveSyncResponse) ProtoMessage()    {}
+func (*StartActiveSyncResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{33}
+}
+func (m *StartActiveSyncResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartActiveSyncResponse.Unmarshal(m, b)
+}
+func (m *StartActiveSyncResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartActiveSyncResponse.Marshal(b, m, deterministic)
+}
+func (m *StartActiveSyncResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartActiveSyncResponse.Merge(m, src)
+}
+func (m *StartActiveSyncResponse) XXX_Size() int {
+	return xxx_messageInfo_StartActiveSyncResponse.Size(m)
+}
+func (m *StartActiveSyncResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartActiveSyncResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartActiveSyncResponse proto.InternalMessageInfo
+
+func (m *StartActiveSyncResponse) GetResult() bool {
+	if m != nil {
+		return m.Result
+	}
+	return false
+}
+
+// Request message of SetHead rpc.
+type SetHeadRequest struct {
+	// The height of the new tail block.
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// Rewind below the fixed block.
+	Force                bool     `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetHeadRequest) Reset()         { *m = SetHeadRequest{} }
+func (m *SetHeadRequest) String() string { return proto.CompactTextString(m) }
+func (*SetHeadRequest) ProtoMessage()    {}
+func (*SetHeadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{34}
+}
+func (m *SetHeadRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetHeadRequest.Unmarshal(m, b)
+}
+func (m *SetHeadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetHeadRequest.Marshal(b, m, deterministic)
+}
+func (m *SetHeadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetHeadRequest.Merge(m, src)
+}
+func (m *SetHeadRequest) XXX_Size() int {
+	return xxx_messageInfo_SetHeadRequest.Size(m)
+}
+func (m *SetHeadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetHeadRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetHeadRequest proto.InternalMessageInfo
+
+func (m *SetHeadRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SetHeadRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
+// Response message of SetHead rpc.
+type SetHeadResponse struct {
+	// The new tail block.
+	Tail *BlockResponse `protobuf:"bytes,1,opt,name=tail,proto3" json:"tail,omitempty"`
+	// The number of discarded blocks.
+	RevertedBlocks uint64 `protobuf:"varint,2,opt,name=reverted_blocks,json=revertedBlocks,proto3" json:"reverted_blocks,omitempty"`
+	// The number of transactions returned to the tx pool.
+	ReturnedTransactions uint64   `protobuf:"varint,3,opt,name=returned_transactions,json=returnedTransactions,proto3" json:"returned_transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetHeadResponse) Reset()         { *m = SetHeadResponse{} }
+func (m *SetHeadResponse) String() string { return proto.CompactTextString(m) }
+func (*SetHeadResponse) ProtoMessage()    {}
+func (*SetHeadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{35}
+}
+func (m *SetHeadResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetHeadResponse.Unmarshal(m, b)
+}
+func (m *SetHeadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetHeadResponse.Marshal(b, m, deterministic)
+}
+func (m *SetHeadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetHeadResponse.Merge(m, src)
+}
+func (m *SetHeadResponse) XXX_Size() int {
+	return xxx_messageInfo_SetHeadResponse.Size(m)
+}
+func (m *SetHeadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetHeadResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetHeadResponse proto.InternalMessageInfo
+
+func (m *SetHeadResponse) GetTail() *BlockResponse {
+	if m != nil {
+		return m.Tail
+	}
+	return nil
+}
+
+func (m *SetHeadResponse) GetRevertedBlocks() uint64 {
+	if m != nil {
+		return m.RevertedBlocks
+	}
+	return 0
+}
+
+func (m *SetHeadResponse) GetReturnedTransactions() uint64 {
+	if m != nil {
+		return m.ReturnedTransactions
+	}
+	return 0
+}
+
+// Request message of CompactStorage rpc.
+type CompactStorageRequest struct {
+	// The hex of the first key to compact, empty from the first key of the storage.
+	Start string `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// The hex of the key after the last key to compact, empty to the last key of the storage.
+	Limit                string   `protobuf:"bytes,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompactStorageRequest) Reset()         { *m = CompactStorageRequest{} }
+func (m *CompactStorageRequest) String() string { return proto.CompactTextString(m) }
+func (*CompactStorageRequest) ProtoMessage()    {}
+func (*CompactStorageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{36}
+}
+func (m *CompactStorageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactStorageRequest.Unmarshal(m, b)
+}
+func (m *CompactStorageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactStorageRequest.Marshal(b, m, deterministic)
+}
+func (m *CompactStorageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactStorageRequest.Merge(m, src)
+}
+func (m *CompactStorageRequest) XXX_Size() int {
+	return xxx_messageInfo_CompactStorageRequest.Size(m)
+}
+func (m *CompactStorageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactStorageRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactStorageRequest proto.InternalMessageInfo
+
+func (m *CompactStorageRequest) GetStart() string {
+	if m != nil {
+		return m.Start
+	}
+	return ""
+}
+
+func (m *CompactStorageRequest) GetLimit() string {
+	if m != nil {
+		return m.Limit
+	}
+	return ""
+}
+
+// Response message of CompactStorage rpc.
+type CompactStorageResponse struct {
+	// The milliseconds spent compacting.
+	Cost                 uint64   `protobuf:"varint,1,opt,name=cost,proto3" json:"cost,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompactStorageResponse) Reset()         { *m = CompactStorageResponse{} }
+func (m *CompactStorageResponse) String() string { return proto.CompactTextString(m) }
+func (*CompactStorageResponse) ProtoMessage()    {}
+func (*CompactStorageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{37}
+}
+func (m *CompactStorageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompactStorageResponse.Unmarshal(m, b)
+}
+func (m *CompactStorageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompactStorageResponse.Marshal(b, m, deterministic)
+}
+func (m *CompactStorageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactStorageResponse.Merge(m, src)
+}
+func (m *CompactStorageResponse) XXX_Size() int {
+	return xxx_messageInfo_CompactStorageResponse.Size(m)
+}
+func (m *CompactStorageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactStorageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactStorageResponse proto.InternalMessageInfo
+
+func (m *CompactStorageResponse) GetCost() uint64 {
+	if m != nil {
+		return m.Cost
+	}
+	return 0
+}
+
+// Request message of Subscribe rpc.
+type SubscribeRequest struct {
+	// The topics to subscribe, chain.newTailBlock, chain.revertBlock, chain.pendingTransaction and chain.addressTransaction.
+	Topics []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	// Base58 string of the account address, required by chain.addressTransaction.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Replay the blocks on canonical chain from the height before the new events, 0 means no replay.
+	FromHeight           uint64   `protobuf:"varint,3,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{38}
+}
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRequest.Unmarshal(m, b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRequest.Size(m)
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetTopics() []string {
+	if m != nil {
+		return m.Topics
+	}
+	return nil
+}
+
+func (m *SubscribeRequest) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SubscribeRequest) GetFromHeight() uint64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
+// Response message of Subscribe rpc.
+type SubscribeResponse struct {
+	// The topic of the event.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The block of chain.newTailBlock and chain.revertBlock events.
+	Block *BlockResponse `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	// The transaction of chain.pendingTransaction and chain.addressTransaction events.
+	Transaction          *TransactionResponse `protobuf:"bytes,3,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SubscribeResponse) Reset()         { *m = SubscribeResponse{} }
+func (m *SubscribeResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeResponse) ProtoMessage()    {}
+func (*SubscribeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_77a6da22d6a3feb1, []int{39}
+}
+func (m *SubscribeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeResponse.Unmarshal(m, b)
+}
+func (m *SubscribeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeResponse.Marshal(b, m, deterministic)
+}
+func (m *SubscribeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeResponse.Merge(m, src)
+}
+func (m *SubscribeResponse) XXX_Size() int {
+	return xxx_messageInfo_SubscribeResponse.Size(m)
+}
+func (m *SubscribeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeResponse proto.InternalMessageInfo
+
+func (m *SubscribeResponse) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *SubscribeResponse) GetBlock() *BlockResponse {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+func (m *SubscribeResponse) GetTransaction() *TransactionResponse {
+	if m != nil {
+		return m.Transaction
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*NonParamsRequest)(nil), "rpcpb.NonParamsRequest")
+	proto.RegisterType((*ChainStateResponse)(nil), "rpcpb.ChainStateResponse")
+	proto.RegisterType((*GetBlockByHashRequest)(nil), "rpcpb.GetBlockByHashRequest")
+	proto.RegisterType((*GetBlockByHeightRequest)(nil), "rpcpb.GetBlockByHeightRequest")
+	proto.RegisterType((*BlockResponse)(nil), "rpcpb.BlockResponse")
+	proto.RegisterType((*GetAccountStateRequest)(nil), "rpcpb.GetAccountStateRequest")
+	proto.RegisterType((*AccountStateResponse)(nil), "rpcpb.AccountStateResponse")
+	proto.RegisterType((*SendRawTransactionRequest)(nil), "rpcpb.SendRawTransactionRequest")
+	proto.RegisterType((*SendTransactionResponse)(nil), "rpcpb.SendTransactionResponse")
+	proto.RegisterType((*GetTransactionRequest)(nil), "rpcpb.GetTransactionRequest")
+	proto.RegisterType((*TransactionResponse)(nil), "rpcpb.TransactionResponse")
+	proto.RegisterType((*NodeInfoResponse)(nil), "rpcpb.NodeInfoResponse")
+	proto.RegisterType((*PeerInfo)(nil), "rpcpb.PeerInfo")
+	proto.RegisterType((*AccountsResponse)(nil), "rpcpb.AccountsResponse")
+	proto.RegisterType((*NewAccountRequest)(nil), "rpcpb.NewAccountRequest")
+	proto.RegisterType((*NewAccountResponse)(nil), "rpcpb.NewAccountResponse")
+	proto.RegisterType((*UnlockAccountRequest)(nil), "rpcpb.UnlockAccountRequest")
+	proto.RegisterType((*UnlockAccountResponse)(nil), "rpcpb.UnlockAccountResponse")
+	proto.RegisterType((*LockAccountRequest)(nil), "rpcpb.LockAccountRequest")
+	proto.RegisterType((*LockAccountResponse)(nil), "rpcpb.LockAccountResponse")
+	proto.RegisterType((*ImportAccountRequest)(nil), "rpcpb.ImportAccountRequest")
+	proto.RegisterType((*ImportAccountResponse)(nil), "rpcpb.ImportAccountResponse")
+	proto.RegisterType((*ExportAccountRequest)(nil), "rpcpb.ExportAccountRequest")
+	proto.RegisterType((*ExportAccountResponse)(nil), "rpcpb.ExportAccountResponse")
+	proto.RegisterType((*SignHashRequest)(nil), "rpcpb.SignHashRequest")
+	proto.RegisterType((*SignHashResponse)(nil), "rpcpb.SignHashResponse")
+	proto.RegisterType((*PeersResponse)(nil), "rpcpb.PeersResponse")
+	proto.RegisterType((*AddPeerRequest)(nil), "rpcpb.AddPeerRequest")
+	proto.RegisterType((*AddPeerResponse)(nil), "rpcpb.AddPeerResponse")
+	proto.RegisterType((*DisconnectPeerRequest)(nil), "rpcpb.DisconnectPeerRequest")
+	proto.RegisterType((*DisconnectPeerResponse)(nil), "rpcpb.DisconnectPeerResponse")
+	proto.RegisterType((*SetMiningRequest)(nil), "rpcpb.SetMiningRequest")
+	proto.RegisterType((*MiningStateResponse)(nil), "rpcpb.MiningStateResponse")
+	proto.RegisterType((*StartActiveSyncResponse)(nil), "rpcpb.StartActiveSyncResponse")
+	proto.RegisterType((*SetHeadRequest)(nil), "rpcpb.SetHeadRequest")
+	proto.RegisterType((*SetHeadResponse)(nil), "rpcpb.SetHeadResponse")
+	proto.RegisterType((*CompactStorageRequest)(nil), "rpcpb.CompactStorageRequest")
+	proto.RegisterType((*CompactStorageResponse)(nil), "rpcpb.CompactStorageResponse")
+	proto.RegisterType((*SubscribeRequest)(nil), "rpcpb.SubscribeRequest")
+	proto.RegisterType((*SubscribeResponse)(nil), "rpcpb.SubscribeResponse")
+}
+
+func init() { proto.RegisterFile("rpc.proto", fileDescriptor_77a6da22d6a3feb1) }
+
+var fileDescriptor_77a6da22d6a3feb1 = []byte{
+	// 2056 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0xdd, 0x6e, 0x1b, 0xc7,
+	0x15, 0x06, 0x49, 0x51, 0x22, 0x0f, 0x25, 0x8a, 0x1a, 0x91, 0xd4, 0x6a, 0x2d, 0xa9, 0xf4, 0x34,
+	0x6d, 0x18, 0xb5, 0x31, 0x6d, 0x19, 0xc8, 0x85, 0x51, 0xa4, 0x50, 0x5c, 0x45, 0x76, 0x9a, 0x06,
+	0xc6, 0xd2, 0x6d, 0x8a, 0x16, 0x05, 0xbb, 0xda, 0x1d, 0x91, 0x03, 0x93, 0xb3, 0xec, 0xce, 0x50,
+	0xa6, 0x74, 0xd9, 0xeb, 0xde, 0xe5, 0xaa, 0xcf, 0xd0, 0x97, 0xe8, 0x3b, 0xf4, 0x0d, 0x82, 0x3e,
+	0x41, 0xd1, 0x07, 0x08, 0xe6, 0x67, 0x7f, 0xb9, 0x94, 0xe0, 0xdc, 0xed, 0x39, 0x33, 0x73, 0xbe,
+	0x99, 0xf3, 0x37, 0xdf, 0x2c, 0xd4, 0xc3, 0xb9, 0xf7, 0x64, 0x1e, 0x06, 0x22, 0x40, 0xd5, 0x70,
+	0xee, 0xcd, 0xaf, 0xec, 0xa3, 0x71, 0x10, 0x8c, 0xa7, 0x64, 0xe0, 0xce, 0xe9, 0xc0, 0x65, 0x2c,
+	0x10, 0xae, 0xa0, 0x01, 0xe3, 0x7a, 0x12, 0x46, 0xd0, 0xfa, 0x26, 0x60, 0x6f, 0xdc, 0xd0, 0x9d,
+	0x71, 0x87, 0xfc, 0x6d, 0x41, 0xb8, 0xc0, 0xff, 0x28, 0x03, 0x7a, 0x39, 0x71, 0x29, 0x1b, 0x0a,
+	0x57, 0x10, 0x87, 0xf0, 0x79, 0xc0, 0x38, 0x41, 0x87, 0x50, 0xf3, 0xa4, 0x76, 0x44, 0x7d, 0xab,
+	0xd4, 0x2b, 0xf5, 0x77, 0x9c, 0x2d, 0x25, 0xbf, 0xf6, 0x11, 0x82, 0x0d, 0xe1, 0xd2, 0xa9, 0x55,
+	0xee, 0x95, 0xfa, 0x75, 0x47, 0x7d, 0xa3, 0x2e, 0x6c, 0x4e, 0x08, 0x1d, 0x4f, 0x84, 0x55, 0xe9,
+	0x95, 0xfa, 0x1b, 0x8e, 0x91, 0x50, 0x1b, 0xaa, 0xd7, 0x74, 0x49, 0x7c, 0x6b, 0x43, 0x4d, 0xd6,
+	0x02, 0x7a, 0x0c, 0xdb, 0xea, 0x63, 0x64, 0xd6, 0x54, 0xd5, 0x9a, 0x86, 0xd2, 0xbd, 0xd2, 0x0b,
+	0x2d, 0xd8, 0x1a, 0x13, 0x46, 0x38, 0xe5, 0xd6, 0xa6, 0x5a, 0x1a, 0x89, 0xe8, 0x08, 0xea, 0x82,
+	0xce, 0x08, 0x17, 0xee, 0x6c, 0x6e, 0x6d, 0xf5, 0x4a, 0xfd, 0x8a, 0x93, 0x28, 0xd0, 0x47, 0xb0,
+	0xc3, 0x6f, 0x99, 0x37, 0x09, 0x03, 0x46, 0xef, 0x28, 0x1b, 0x5b, 0xb5, 0x5e, 0xa9, 0x5f, 0x73,
+	0xb2, 0x4a, 0x69, 0xfd, 0x86, 0x84, 0x9c, 0x06, 0xcc, 0xaa, 0x6b, 0xeb, 0x46, 0xc4, 0x23, 0xe8,
+	0x5c, 0x12, 0xf1, 0xc5, 0x34, 0xf0, 0xde, 0x7d, 0x71, 0xfb, 0xca, 0xe5, 0x13, 0xe3, 0x27, 0x79,
+	0xea, 0x89, 0xcb, 0x27, 0xca, 0x19, 0x75, 0x47, 0x7d, 0xa3, 0x33, 0xe8, 0x5c, 0x2f, 0xa6, 0xd3,
+	0xd1, 0x35, 0x9d, 0x4e, 0x47, 0x22, 0x74, 0x19, 0x77, 0x3d, 0xe9, 0x6f, 0xe5, 0x9a, 0x9a, 0xb3,
+	0x2f, 0x07, 0xbf, 0xa4, 0xd3, 0xe9, 0xdb, 0x64, 0x08, 0x13, 0x38, 0x48, 0x01, 0xa8, 0xc3, 0x46,
+	0x10, 0x89, 0x13, 0x4b, 0x19, 0x27, 0xfe, 0x18, 0x98, 0xff, 0x95, 0x61, 0x47, 0x81, 0xc4, 0x11,
+	0x2d, 0x3a, 0xc0, 0x4f, 0xa0, 0x31, 0x77, 0x43, 0xc2, 0xc4, 0x48, 0x0d, 0xe9, 0x88, 0x82, 0x56,
+	0xc9, 0xc3, 0xaf, 0x8d, 0x6b, 0x26, 0x08, 0x1b, 0xf9, 0x20, 0xa4, 0x93, 0xa7, 0x9a, 0x4d, 0x1e,
+	0x1b, 0x6a, 0x5e, 0x40, 0xd9, 0x95, 0xcb, 0x89, 0x09, 0x6c, 0x2c, 0xa3, 0x63, 0x00, 0x2e, 0x93,
+	0x70, 0x14, 0x06, 0x81, 0x50, 0xa1, 0xad, 0x3b, 0x75, 0xa5, 0x71, 0x82, 0x40, 0x48, 0xab, 0x62,
+	0xc9, 0xf5, 0x60, 0x4d, 0x47, 0x4d, 0x2c, 0xb9, 0x1a, 0xea, 0xc2, 0x26, 0xa7, 0x63, 0x46, 0x42,
+	0x13, 0x4e, 0x23, 0xa1, 0x4f, 0x01, 0xa5, 0xfc, 0xa5, 0x0e, 0x49, 0xb8, 0x05, 0xbd, 0x4a, 0xbf,
+	0xee, 0xec, 0xa5, 0x46, 0x5e, 0xa9, 0x01, 0xf4, 0x39, 0x6c, 0xa7, 0x94, 0xdc, 0x6a, 0xf4, 0x2a,
+	0xfd, 0xc6, 0x99, 0xfd, 0x44, 0xd5, 0xd6, 0x93, 0x94, 0x7b, 0x23, 0xa7, 0x3a, 0x99, 0xf9, 0xf8,
+	0x2b, 0xe8, 0x5e, 0x12, 0x71, 0xee, 0x79, 0xc1, 0x82, 0x09, 0x53, 0x4f, 0x3a, 0xb4, 0x16, 0x6c,
+	0xb9, 0xbe, 0x1f, 0x12, 0xce, 0x8d, 0xff, 0x23, 0x31, 0xe5, 0xe1, 0x72, 0xda, 0xc3, 0xf8, 0x5f,
+	0x25, 0x68, 0x67, 0x2d, 0x99, 0x38, 0x5a, 0xb0, 0x75, 0xe5, 0x4e, 0x5d, 0xe6, 0x91, 0xc8, 0x94,
+	0x11, 0x65, 0x34, 0xaf, 0xc3, 0xe0, 0x8e, 0xb0, 0xd1, 0xf5, 0x82, 0xf9, 0x51, 0x34, 0xb5, 0xea,
+	0xcb, 0x05, 0xf3, 0x55, 0xb8, 0xa7, 0xc4, 0x1f, 0x13, 0x3d, 0xa1, 0x62, 0xc2, 0xad, 0x54, 0x6a,
+	0x42, 0x1b, 0xaa, 0x2c, 0x90, 0x96, 0x37, 0xd4, 0x5e, 0xb4, 0x20, 0xcb, 0xd5, 0x0b, 0x89, 0x4f,
+	0xc5, 0x88, 0x32, 0x9f, 0x2c, 0x55, 0x48, 0xeb, 0x4e, 0x43, 0xeb, 0x5e, 0x4b, 0x15, 0x1e, 0xc0,
+	0xe1, 0x90, 0x30, 0xdf, 0x71, 0xdf, 0x67, 0xbc, 0x14, 0x97, 0x8e, 0xef, 0x0a, 0x57, 0x6d, 0x77,
+	0xdb, 0x51, 0xdf, 0xf8, 0x19, 0x1c, 0xc8, 0x05, 0x05, 0x3e, 0x95, 0x1e, 0x11, 0xcb, 0x54, 0xaa,
+	0x1a, 0x09, 0xff, 0x42, 0x95, 0x66, 0xb1, 0xfd, 0x7c, 0x66, 0xe3, 0xef, 0xcb, 0xb0, 0x5f, 0x64,
+	0xbc, 0xa8, 0x0a, 0xd2, 0xe9, 0x5a, 0x5e, 0xe9, 0x75, 0xd7, 0x61, 0x30, 0x33, 0xae, 0x52, 0xdf,
+	0xa8, 0x09, 0x65, 0x11, 0x98, 0x86, 0x56, 0x16, 0x81, 0x74, 0xda, 0x8d, 0x3b, 0x5d, 0x10, 0xe3,
+	0x17, 0x2d, 0x24, 0xae, 0xdc, 0x4c, 0xbb, 0xb2, 0x05, 0x95, 0x6b, 0x42, 0x4c, 0x6e, 0xcb, 0xcf,
+	0x6c, 0x25, 0xd5, 0xf2, 0x95, 0x24, 0x7b, 0xed, 0xed, 0x9c, 0x98, 0xb4, 0x56, 0xdf, 0xb1, 0x3b,
+	0x21, 0x71, 0xa7, 0x2c, 0xab, 0x79, 0x48, 0x83, 0x90, 0x8a, 0x5b, 0xab, 0xa1, 0x8e, 0x10, 0xcb,
+	0xb2, 0xac, 0xae, 0x64, 0x27, 0xd0, 0x35, 0xbe, 0xad, 0xcb, 0x4a, 0x69, 0x54, 0x89, 0x3f, 0x86,
+	0x6d, 0x33, 0xac, 0xd3, 0x70, 0x47, 0x37, 0x63, 0x3d, 0x41, 0xa9, 0x54, 0x79, 0x09, 0x57, 0x2c,
+	0xb8, 0xd5, 0xec, 0x95, 0xfa, 0x55, 0xc7, 0x48, 0xf8, 0xdf, 0x25, 0x79, 0xa1, 0xf8, 0xe4, 0x35,
+	0xbb, 0x0e, 0x62, 0x0f, 0x37, 0xa1, 0x6c, 0xee, 0x8c, 0xba, 0x53, 0xa6, 0xfe, 0x7d, 0xde, 0xed,
+	0xc2, 0xe6, 0x94, 0x72, 0x41, 0x98, 0x55, 0x51, 0x25, 0x69, 0xa4, 0x74, 0x7b, 0xde, 0xc8, 0xb4,
+	0x67, 0xd4, 0x87, 0x56, 0x18, 0x2c, 0x04, 0x19, 0x09, 0xf7, 0x6a, 0x4a, 0x46, 0x9c, 0xde, 0x69,
+	0xb7, 0x57, 0x9d, 0xa6, 0xd2, 0xbf, 0x95, 0xea, 0x21, 0xbd, 0x23, 0xe8, 0x67, 0x50, 0x9d, 0x13,
+	0x12, 0xca, 0xeb, 0x43, 0x16, 0xf1, 0xae, 0x29, 0xe2, 0x37, 0x84, 0x84, 0x6a, 0xbb, 0x7a, 0x14,
+	0x7f, 0x0b, 0xb5, 0x48, 0xb5, 0xb2, 0xf3, 0x54, 0xd1, 0x96, 0xb3, 0x45, 0x2b, 0x2b, 0x22, 0x60,
+	0x8c, 0x78, 0x82, 0xf8, 0x23, 0x57, 0x37, 0xc7, 0x8a, 0xd3, 0x88, 0x75, 0xe7, 0x02, 0x3f, 0x85,
+	0x96, 0x29, 0x5f, 0x1e, 0xbb, 0xe6, 0x08, 0xea, 0xc6, 0x02, 0x91, 0x7d, 0x40, 0x1e, 0x39, 0x51,
+	0xe0, 0xe7, 0xb0, 0xf7, 0x0d, 0x79, 0x6f, 0x16, 0x45, 0xb9, 0x7d, 0x02, 0x30, 0x77, 0x39, 0x9f,
+	0x4f, 0x42, 0xd9, 0x31, 0x4b, 0x51, 0x83, 0x8e, 0x34, 0xf8, 0x2b, 0x40, 0xe9, 0x45, 0x49, 0x8f,
+	0x58, 0xd3, 0x6e, 0x6c, 0xa8, 0xcd, 0x18, 0x99, 0x05, 0x8c, 0x7a, 0xe6, 0x50, 0xb1, 0x8c, 0xa7,
+	0xd0, 0xfe, 0x3d, 0x93, 0x61, 0xcf, 0xed, 0x61, 0xbd, 0xb5, 0xec, 0xee, 0xca, 0xf9, 0xdd, 0x49,
+	0x34, 0x7f, 0x11, 0x2a, 0x0e, 0x62, 0x2e, 0x90, 0x58, 0xc6, 0x03, 0xe8, 0xe4, 0xd0, 0x92, 0xfa,
+	0x0f, 0x09, 0x5f, 0x4c, 0xf5, 0x35, 0x58, 0x73, 0x8c, 0x84, 0x9f, 0x00, 0xfa, 0xfa, 0x03, 0x36,
+	0x87, 0x3f, 0x85, 0xfd, 0xaf, 0x3f, 0xc0, 0x3c, 0x85, 0xf6, 0xeb, 0xd9, 0x3c, 0x08, 0x45, 0x0e,
+	0xa0, 0x05, 0x95, 0x77, 0xe4, 0xd6, 0x34, 0x2f, 0xf9, 0xa9, 0xda, 0x68, 0x48, 0x6f, 0xe4, 0x4d,
+	0x25, 0x47, 0xca, 0x6a, 0x04, 0x8c, 0xea, 0xb7, 0xe4, 0x36, 0xe7, 0x96, 0xca, 0x4a, 0xd0, 0x9e,
+	0x41, 0x27, 0x07, 0xf5, 0x50, 0xdc, 0xf0, 0x1b, 0x68, 0x5f, 0x2c, 0x0b, 0x76, 0xf7, 0xa3, 0x63,
+	0x83, 0x3f, 0x81, 0xce, 0xc5, 0xb2, 0x68, 0x13, 0x2b, 0x07, 0xc6, 0xbf, 0x86, 0xdd, 0x21, 0x1d,
+	0xb3, 0x34, 0x1d, 0x5a, 0x8f, 0x1b, 0x75, 0x58, 0xed, 0x16, 0xf5, 0x8d, 0x7f, 0x0e, 0xad, 0xc4,
+	0x40, 0xd2, 0x89, 0x57, 0x6e, 0x85, 0xcf, 0x60, 0x47, 0x56, 0x63, 0x52, 0x31, 0x71, 0x15, 0x97,
+	0xee, 0xad, 0xe2, 0xcf, 0xa0, 0x79, 0xee, 0xfb, 0x52, 0x1b, 0xed, 0x2f, 0x5f, 0xcb, 0x6d, 0xa8,
+	0xca, 0x0d, 0xca, 0x4a, 0x96, 0x65, 0xa7, 0x05, 0xfc, 0x09, 0xec, 0xc6, 0xeb, 0x1e, 0x48, 0x8f,
+	0x8f, 0xa1, 0xf3, 0x1b, 0xca, 0x4d, 0x85, 0xdf, 0x83, 0x84, 0x9f, 0x42, 0x37, 0x3f, 0xf1, 0x01,
+	0xd3, 0xa7, 0xd0, 0x1a, 0x12, 0xf1, 0x3b, 0xca, 0x28, 0x1b, 0xa7, 0xb8, 0xa0, 0x21, 0x79, 0xe6,
+	0x12, 0xd4, 0x12, 0xbe, 0x84, 0x7d, 0x3d, 0x31, 0x4b, 0x0a, 0xba, 0xb0, 0x49, 0x98, 0xec, 0x7d,
+	0x91, 0x69, 0x2d, 0xc9, 0x30, 0xf1, 0x05, 0x9f, 0x13, 0x43, 0x07, 0x6a, 0x4e, 0x24, 0xaa, 0x0b,
+	0x58, 0xb8, 0x32, 0xfa, 0x82, 0xde, 0x90, 0xe1, 0x2d, 0xf3, 0x1e, 0xdc, 0xe7, 0xe7, 0xd0, 0x1c,
+	0x12, 0xf1, 0x8a, 0xb8, 0xfe, 0x43, 0x8c, 0x55, 0xd2, 0xfe, 0x20, 0xf4, 0x88, 0x01, 0xd5, 0x02,
+	0xfe, 0x67, 0x09, 0x76, 0x63, 0x03, 0x06, 0xab, 0x6f, 0x1e, 0x13, 0x72, 0x7d, 0xe3, 0xac, 0x6d,
+	0xe2, 0x9b, 0x61, 0xae, 0xe6, 0x89, 0xf1, 0x31, 0xec, 0x86, 0xe4, 0x86, 0x84, 0xb2, 0xe5, 0xaa,
+	0xcb, 0x89, 0x1b, 0xc6, 0xd4, 0x8c, 0xd4, 0x6a, 0x15, 0x47, 0xcf, 0xa1, 0x13, 0x12, 0xb1, 0x08,
+	0x19, 0xf1, 0x47, 0x19, 0x3a, 0xa7, 0x3b, 0x50, 0x3b, 0x1a, 0x4c, 0xd1, 0x03, 0x8e, 0x5f, 0x42,
+	0xe7, 0x65, 0x30, 0x9b, 0xbb, 0x9e, 0x18, 0x8a, 0x20, 0x74, 0xc7, 0x31, 0x73, 0x6b, 0x43, 0x95,
+	0x4b, 0x3f, 0x99, 0x38, 0x68, 0x41, 0x6a, 0xa7, 0x74, 0x46, 0x85, 0xa9, 0x2b, 0x2d, 0xe0, 0x5f,
+	0x42, 0x37, 0x6f, 0x24, 0x49, 0x76, 0x2f, 0xe0, 0x91, 0x9b, 0xd4, 0x37, 0x26, 0xd0, 0x1a, 0x2e,
+	0xae, 0xb8, 0x17, 0xd2, 0x2b, 0x92, 0x72, 0xa8, 0x08, 0xe6, 0xd4, 0x8b, 0xae, 0x07, 0x23, 0xdd,
+	0x73, 0x15, 0x69, 0xd2, 0x37, 0x1b, 0x65, 0x68, 0xba, 0x24, 0x7d, 0x33, 0x7d, 0x79, 0xe3, 0xef,
+	0x4a, 0xb0, 0x97, 0xc2, 0x31, 0x1b, 0x6a, 0x43, 0x55, 0x99, 0x8e, 0x8e, 0xa5, 0x04, 0x74, 0x0a,
+	0x55, 0xe5, 0x5a, 0xab, 0x7c, 0x4f, 0x38, 0xf4, 0x14, 0xf4, 0x2b, 0x68, 0xa4, 0xdf, 0x22, 0x95,
+	0x5e, 0xe9, 0x01, 0xae, 0x9c, 0x9e, 0x7e, 0xf6, 0xfd, 0x26, 0xc0, 0xf9, 0x9c, 0x0e, 0x49, 0x78,
+	0x43, 0x3d, 0x82, 0xfe, 0x0a, 0x3b, 0x97, 0x44, 0x24, 0xef, 0x50, 0x74, 0x60, 0x0c, 0xe5, 0xdf,
+	0xab, 0xf6, 0xa1, 0x19, 0x58, 0x7d, 0xb3, 0xe2, 0x47, 0x7f, 0xff, 0xcf, 0x7f, 0xbf, 0x2b, 0x77,
+	0xd0, 0xfe, 0xe0, 0xe6, 0xd9, 0x60, 0xc1, 0x49, 0x38, 0x50, 0x44, 0x43, 0xbd, 0x20, 0xd0, 0x04,
+	0x9a, 0xd9, 0x87, 0x1d, 0x3a, 0x32, 0x96, 0x0a, 0xdf, 0x7b, 0x76, 0xe1, 0xd9, 0x31, 0x56, 0x10,
+	0x47, 0xf8, 0x20, 0x86, 0x18, 0x67, 0x56, 0xbf, 0x28, 0x9d, 0x22, 0x06, 0xad, 0xfc, 0x0b, 0x0f,
+	0x9d, 0xac, 0x62, 0xa5, 0x9f, 0x7e, 0x6b, 0xd0, 0x3e, 0x52, 0x68, 0x27, 0xf8, 0xb0, 0x08, 0x4d,
+	0xad, 0x97, 0x78, 0x01, 0xec, 0xe6, 0x5e, 0x1d, 0xe8, 0x38, 0x81, 0x2b, 0x78, 0x8d, 0xd8, 0x8f,
+	0xcc, 0x70, 0xd1, 0xfb, 0x02, 0xf7, 0x14, 0xa8, 0x8d, 0x3b, 0x31, 0xa8, 0xab, 0xa7, 0x29, 0x3f,
+	0x4a, 0xc0, 0x3b, 0x40, 0xab, 0x64, 0x1f, 0xf5, 0x8c, 0xd1, 0xb5, 0xef, 0x00, 0xfb, 0x24, 0x35,
+	0xa3, 0x20, 0x41, 0x0a, 0x9c, 0x1b, 0xba, 0xef, 0x53, 0x49, 0xa3, 0x9d, 0xdb, 0xcc, 0x3e, 0x02,
+	0xd2, 0x61, 0x2c, 0xc0, 0xbc, 0x27, 0x21, 0x8b, 0x83, 0xf9, 0x36, 0x8b, 0xf7, 0x27, 0x68, 0x5c,
+	0x12, 0x11, 0x91, 0xdc, 0xf5, 0x69, 0x99, 0x0c, 0x64, 0xe9, 0x30, 0x3e, 0x54, 0x20, 0xfb, 0x68,
+	0x2f, 0x06, 0x61, 0x81, 0x4f, 0xa8, 0x34, 0x36, 0x82, 0x7a, 0x5c, 0x98, 0xb1, 0xe5, 0x7c, 0x4b,
+	0xb0, 0xad, 0xd5, 0x01, 0x63, 0xfa, 0x58, 0x99, 0x3e, 0xc0, 0x28, 0x36, 0xcd, 0xa3, 0x39, 0x2f,
+	0x4a, 0xa7, 0x4f, 0x4b, 0x67, 0xff, 0x6f, 0xc0, 0xf6, 0xb9, 0x3f, 0xa3, 0x2c, 0x2a, 0xb3, 0x3f,
+	0x42, 0x2d, 0x22, 0xa5, 0x0f, 0x1f, 0x25, 0x4f, 0x5f, 0xb1, 0xad, 0xf0, 0xda, 0x48, 0xe1, 0xb9,
+	0xd2, 0x6e, 0x9c, 0x1a, 0xc8, 0x03, 0x48, 0x78, 0x28, 0x8a, 0xf6, 0xbc, 0xc2, 0x67, 0xed, 0xc3,
+	0x82, 0x91, 0xa2, 0xc4, 0xcb, 0x98, 0x1f, 0x30, 0xf2, 0x5e, 0x67, 0xfa, 0x4e, 0x86, 0x32, 0xa2,
+	0x28, 0x91, 0x8b, 0x68, 0xab, 0x7d, 0x54, 0x3c, 0x68, 0xd0, 0x7e, 0xaa, 0xd0, 0x8e, 0xb1, 0xb5,
+	0x8a, 0xb6, 0x50, 0x0b, 0x24, 0xe0, 0x18, 0x1a, 0x29, 0x0a, 0x89, 0xa2, 0xcd, 0xaf, 0xd2, 0x50,
+	0xdb, 0x2e, 0x1a, 0x32, 0x50, 0x8f, 0x15, 0xd4, 0x23, 0xdc, 0x5d, 0x85, 0x8a, 0x80, 0x02, 0xd8,
+	0xc9, 0x30, 0xc2, 0xf8, 0x64, 0x45, 0x94, 0xd4, 0x3e, 0x2a, 0x1e, 0x7c, 0xf8, 0x64, 0x54, 0x2d,
+	0x30, 0x80, 0x17, 0xcb, 0x22, 0xc0, 0x8b, 0xe5, 0x3d, 0x80, 0x17, 0xcb, 0x0f, 0x04, 0x24, 0xcb,
+	0x08, 0xf0, 0xcf, 0x50, 0x8b, 0x28, 0x20, 0xea, 0x46, 0x29, 0x9d, 0x25, 0x95, 0xf6, 0xc1, 0x8a,
+	0xde, 0x20, 0x9c, 0x28, 0x04, 0x0b, 0xef, 0x27, 0x08, 0xf2, 0x0f, 0xcf, 0x60, 0x62, 0x5a, 0xae,
+	0x03, 0xb5, 0x4b, 0xa2, 0xc8, 0xd6, 0x3d, 0x79, 0xdd, 0x4e, 0x91, 0xc7, 0x24, 0xa9, 0x0f, 0x94,
+	0xe9, 0x3d, 0xb4, 0x9b, 0x98, 0x56, 0x9c, 0x12, 0x7d, 0x0b, 0x5b, 0x86, 0x1b, 0xa2, 0x4e, 0x54,
+	0x11, 0x19, 0x8e, 0x69, 0x77, 0xf3, 0xea, 0xa2, 0xba, 0x4c, 0x4c, 0x0e, 0x5c, 0xdf, 0x97, 0x9b,
+	0xe5, 0xd0, 0xcc, 0x12, 0xc4, 0xb8, 0x85, 0x15, 0x12, 0x4c, 0xfb, 0x78, 0xcd, 0x68, 0xd1, 0x25,
+	0x91, 0x42, 0xf3, 0xe3, 0xe9, 0x12, 0xf4, 0x2f, 0x50, 0x8f, 0x39, 0x66, 0xd2, 0x6b, 0x72, 0xac,
+	0x33, 0xce, 0xe2, 0x02, 0x8a, 0x19, 0xdd, 0xae, 0xb8, 0x95, 0xe0, 0xcc, 0xd4, 0x34, 0x69, 0x7e,
+	0xa4, 0xda, 0x72, 0x6a, 0xd9, 0xfa, 0x30, 0xdc, 0x87, 0x61, 0x29, 0x0c, 0x84, 0x56, 0x30, 0x10,
+	0x81, 0xdd, 0x1c, 0x5d, 0x5d, 0x8f, 0x10, 0xdf, 0x33, 0xc5, 0xfc, 0x36, 0x6a, 0xc9, 0xb8, 0x99,
+	0xa0, 0xc8, 0xdf, 0xc3, 0xf2, 0x1c, 0x7f, 0x80, 0x2d, 0xc3, 0x50, 0xe3, 0xa0, 0x67, 0x29, 0xaf,
+	0xdd, 0xcd, 0xab, 0x8d, 0xd1, 0x23, 0x65, 0xb4, 0x8b, 0xf7, 0x52, 0x46, 0x89, 0x98, 0x10, 0x57,
+	0xc5, 0xfc, 0x1d, 0x34, 0xb3, 0xcc, 0x30, 0x8e, 0x79, 0x21, 0xeb, 0xb4, 0x8f, 0xd7, 0x8c, 0xae,
+	0x07, 0xf3, 0xf4, 0xcc, 0x17, 0xa5, 0xd3, 0xab, 0x4d, 0xf5, 0xb7, 0xff, 0xf9, 0x0f, 0x03, 0x00,
+	0x32, 0x92, 0xf1, 0xaf, 0x1f, 0x18, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ApiServiceClient is the client API for ApiService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ApiServiceClient interface {
+	// Return the state of the chain.
+	GetChainState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ChainStateResponse, error)
+	// Return the block with the given hash.
+	GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	// Return the block on canonical chain with the given height.
+	GetBlockByHeight(ctx context.Context, in *GetBlockByHeightRequest, opts ...grpc.CallOption) (*BlockResponse, error)
+	// Return the state of the account.
+	GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*AccountStateResponse, error)
+	// Submit a signed transaction.
+	SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	// Return the transaction with the given hash.
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error)
+	// Return the info of the node and its peers.
+	GetNodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error)
+	// Subscribe the chain events.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error)
+}
+
+type apiServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewApiServiceClient(cc *grpc.ClientConn) ApiServiceClient {
+	return &apiServiceClient{cc}
+}
+
+func (c *apiServiceClient) GetChainState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*ChainStateResponse, error) {
+	out := new(ChainStateResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetChainState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetBlockByHash(ctx context.Context, in *GetBlockByHashRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	out := new(BlockResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetBlockByHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetBlockByHeight(ctx context.Context, in *GetBlockByHeightRequest, opts ...grpc.CallOption) (*BlockResponse, error) {
+	out := new(BlockResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetBlockByHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetAccountState(ctx context.Context, in *GetAccountStateRequest, opts ...grpc.CallOption) (*AccountStateResponse, error) {
+	out := new(AccountStateResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetAccountState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) SendRawTransaction(ctx context.Context, in *SendRawTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/SendRawTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*TransactionResponse, error) {
+	out := new(TransactionResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) GetNodeInfo(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*NodeInfoResponse, error) {
+	out := new(NodeInfoResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.ApiService/GetNodeInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ApiService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ApiService_serviceDesc.Streams[0], "/rpcpb.ApiService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &apiServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ApiService_SubscribeClient interface {
+	Recv() (*SubscribeResponse, error)
+	grpc.ClientStream
+}
+
+type apiServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *apiServiceSubscribeClient) Recv() (*SubscribeResponse, error) {
+	m := new(SubscribeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ApiServiceServer is the server API for ApiService service.
+type ApiServiceServer interface {
+	// Return the state of the chain.
+	GetChainState(context.Context, *NonParamsRequest) (*ChainStateResponse, error)
+	// Return the block with the given hash.
+	GetBlockByHash(context.Context, *GetBlockByHashRequest) (*BlockResponse, error)
+	// Return the block on canonical chain with the given height.
+	GetBlockByHeight(context.Context, *GetBlockByHeightRequest) (*BlockResponse, error)
+	// Return the state of the account.
+	GetAccountState(context.Context, *GetAccountStateRequest) (*AccountStateResponse, error)
+	// Submit a signed transaction.
+	SendRawTransaction(context.Context, *SendRawTransactionRequest) (*SendTransactionResponse, error)
+	// Return the transaction with the given hash.
+	GetTransaction(context.Context, *GetTransactionRequest) (*TransactionResponse, error)
+	// Return the info of the node and its peers.
+	GetNodeInfo(context.Context, *NonParamsRequest) (*NodeInfoResponse, error)
+	// Subscribe the chain events.
+	Subscribe(*SubscribeRequest, ApiService_SubscribeServer) error
+}
+
+// UnimplementedApiServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedApiServiceServer struct {
+}
+
+func (*UnimplementedApiServiceServer) GetChainState(ctx context.Context, req *NonParamsRequest) (*ChainStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChainState not implemented")
+}
+func (*UnimplementedApiServiceServer) GetBlockByHash(ctx context.Context, req *GetBlockByHashRequest) (*BlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHash not implemented")
+}
+func (*UnimplementedApiServiceServer) GetBlockByHeight(ctx context.Context, req *GetBlockByHeightRequest) (*BlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHeight not implemented")
+}
+func (*UnimplementedApiServiceServer) GetAccountState(ctx context.Context, req *GetAccountStateRequest) (*AccountStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountState not implemented")
+}
+func (*UnimplementedApiServiceServer) SendRawTransaction(ctx context.Context, req *SendRawTransactionRequest) (*SendTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendRawTransaction not implemented")
+}
+func (*UnimplementedApiServiceServer) GetTransaction(ctx context.Context, req *GetTransactionRequest) (*TransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (*UnimplementedApiServiceServer) GetNodeInfo(ctx context.Context, req *NonParamsRequest) (*NodeInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeInfo not implemented")
+}
+func (*UnimplementedApiServiceServer) Subscribe(req *SubscribeRequest, srv ApiService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+
+func RegisterApiServiceServer(s *grpc.Server, srv ApiServiceServer) {
+	s.RegisterService(&_ApiService_serviceDesc, srv)
+}
+
+func _ApiService_GetChainState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetChainState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetChainState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetChainState(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetBlockByHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockByHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBlockByHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBlockByHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBlockByHash(ctx, req.(*GetBlockByHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetBlockByHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockByHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetBlockByHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetBlockByHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetBlockByHeight(ctx, req.(*GetBlockByHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetAccountState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetAccountState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetAccountState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetAccountState(ctx, req.(*GetAccountStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_SendRawTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRawTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).SendRawTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/SendRawTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).SendRawTransaction(ctx, req.(*SendRawTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServiceServer).GetNodeInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.ApiService/GetNodeInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServiceServer).GetNodeInfo(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApiService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApiServiceServer).Subscribe(m, &apiServiceSubscribeServer{stream})
+}
+
+type ApiService_SubscribeServer interface {
+	Send(*SubscribeResponse) error
+	grpc.ServerStream
+}
+
+type apiServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *apiServiceSubscribeServer) Send(m *SubscribeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _ApiService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.ApiService",
+	HandlerType: (*ApiServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetChainState",
+			Handler:    _ApiService_GetChainState_Handler,
+		},
+		{
+			MethodName: "GetBlockByHash",
+			Handler:    _ApiService_GetBlockByHash_Handler,
+		},
+		{
+			MethodName: "GetBlockByHeight",
+			Handler:    _ApiService_GetBlockByHeight_Handler,
+		},
+		{
+			MethodName: "GetAccountState",
+			Handler:    _ApiService_GetAccountState_Handler,
+		},
+		{
+			MethodName: "SendRawTransaction",
+			Handler:    _ApiService_SendRawTransaction_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _ApiService_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetNodeInfo",
+			Handler:    _ApiService_GetNodeInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _ApiService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc.proto",
+}
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// Return the addresses of the local accounts.
+	Accounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*AccountsResponse, error)
+	// Create a new account with the passphrase.
+	NewAccount(ctx context.Context, in *NewAccountRequest, opts ...grpc.CallOption) (*NewAccountResponse, error)
+	// Unlock the account with the passphrase for a duration.
+	UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error)
+	// Lock the account.
+	LockAccount(ctx context.Context, in *LockAccountRequest, opts ...grpc.CallOption) (*LockAccountResponse, error)
+	// Import an account from a key file or a private key.
+	ImportAccount(ctx context.Context, in *ImportAccountRequest, opts ...grpc.CallOption) (*ImportAccountResponse, error)
+	// Export the key file of the account.
+	ExportAccount(ctx context.Context, in *ExportAccountRequest, opts ...grpc.CallOption) (*ExportAccountResponse, error)
+	// Sign the hash with an unlocked account.
+	SignHash(ctx context.Context, in *SignHashRequest, opts ...grpc.CallOption) (*SignHashResponse, error)
+	// Return the connected peers.
+	GetPeers(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*PeersResponse, error)
+	// Add a peer to the route table.
+	AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerResponse, error)
+	// Disconnect the peer.
+	DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error)
+	// Control the mining of the consensus.
+	SetMining(ctx context.Context, in *SetMiningRequest, opts ...grpc.CallOption) (*MiningStateResponse, error)
+	// Return the mining state of the consensus.
+	GetMiningState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*MiningStateResponse, error)
+	// Start an active sync task.
+	StartActiveSync(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*StartActiveSyncResponse, error)
+	// Rewind the canonical chain to the given height.
+	SetHead(ctx context.Context, in *SetHeadRequest, opts ...grpc.CallOption) (*SetHeadResponse, error)
+	// Compact a key range of the storage.
+	CompactStorage(ctx context.Context, in *CompactStorageRequest, opts ...grpc.CallOption) (*CompactStorageResponse, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) Accounts(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*AccountsResponse, error) {
+	out := new(AccountsResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/Accounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) NewAccount(ctx context.Context, in *NewAccountRequest, opts ...grpc.CallOption) (*NewAccountResponse, error) {
+	out := new(NewAccountResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/NewAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error) {
+	out := new(UnlockAccountResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/UnlockAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) LockAccount(ctx context.Context, in *LockAccountRequest, opts ...grpc.CallOption) (*LockAccountResponse, error) {
+	out := new(LockAccountResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/LockAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ImportAccount(ctx context.Context, in *ImportAccountRequest, opts ...grpc.CallOption) (*ImportAccountResponse, error) {
+	out := new(ImportAccountResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/ImportAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ExportAccount(ctx context.Context, in *ExportAccountRequest, opts ...grpc.CallOption) (*ExportAccountResponse, error) {
+	out := new(ExportAccountResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/ExportAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SignHash(ctx context.Context, in *SignHashRequest, opts ...grpc.CallOption) (*SignHashResponse, error) {
+	out := new(SignHashResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/SignHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetPeers(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*PeersResponse, error) {
+	out := new(PeersResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/GetPeers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerResponse, error) {
+	out := new(AddPeerResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/AddPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DisconnectPeer(ctx context.Context, in *DisconnectPeerRequest, opts ...grpc.CallOption) (*DisconnectPeerResponse, error) {
+	out := new(DisconnectPeerResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/DisconnectPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetMining(ctx context.Context, in *SetMiningRequest, opts ...grpc.CallOption) (*MiningStateResponse, error) {
+	out := new(MiningStateResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/SetMining", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetMiningState(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*MiningStateResponse, error) {
+	out := new(MiningStateResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/GetMiningState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) StartActiveSync(ctx context.Context, in *NonParamsRequest, opts ...grpc.CallOption) (*StartActiveSyncResponse, error) {
+	out := new(StartActiveSyncResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/StartActiveSync", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetHead(ctx context.Context, in *SetHeadRequest, opts ...grpc.CallOption) (*SetHeadResponse, error) {
+	out := new(SetHeadResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/SetHead", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) CompactStorage(ctx context.Context, in *CompactStorageRequest, opts ...grpc.CallOption) (*CompactStorageResponse, error) {
+	out := new(CompactStorageResponse)
+	err := c.cc.Invoke(ctx, "/rpcpb.AdminService/CompactStorage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	// Return the addresses of the local accounts.
+	Accounts(context.Context, *NonParamsRequest) (*AccountsResponse, error)
+	// Create a new account with the passphrase.
+	NewAccount(context.Context, *NewAccountRequest) (*NewAccountResponse, error)
+	// Unlock the account with the passphrase for a duration.
+	UnlockAccount(context.Context, *UnlockAccountRequest) (*UnlockAccountResponse, error)
+	// Lock the account.
+	LockAccount(context.Context, *LockAccountRequest) (*LockAccountResponse, error)
+	// Import an account from a key file or a private key.
+	ImportAccount(context.Context, *ImportAccountRequest) (*ImportAccountResponse, error)
+	// Export the key file of the account.
+	ExportAccount(context.Context, *ExportAccountRequest) (*ExportAccountResponse, error)
+	// Sign the hash with an unlocked account.
+	SignHash(context.Context, *SignHashRequest) (*SignHashResponse, error)
+	// Return the connected peers.
+	GetPeers(context.Context, *NonParamsRequest) (*PeersResponse, error)
+	// Add a peer to the route table.
+	AddPeer(context.Context, *AddPeerRequest) (*AddPeerResponse, error)
+	// Disconnect the peer.
+	DisconnectPeer(context.Context, *DisconnectPeerRequest) (*DisconnectPeerResponse, error)
+	// Control the mining of the consensus.
+	SetMining(context.Context, *SetMiningRequest) (*MiningStateResponse, error)
+	// Return the mining state of the consensus.
+	GetMiningState(context.Context, *NonParamsRequest) (*MiningStateResponse, error)
+	// Start an active sync task.
+	StartActiveSync(context.Context, *NonParamsRequest) (*StartActiveSyncResponse, error)
+	// Rewind the canonical chain to the given height.
+	SetHead(context.Context, *SetHeadRequest) (*SetHeadResponse, error)
+	// Compact a key range of the storage.
+	CompactStorage(context.Context, *CompactStorageRequest) (*CompactStorageResponse, error)
+}
+
+// UnimplementedAdminServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedAdminServiceServer struct {
+}
+
+func (*UnimplementedAdminServiceServer) Accounts(ctx context.Context, req *NonParamsRequest) (*AccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Accounts not implemented")
+}
+func (*UnimplementedAdminServiceServer) NewAccount(ctx context.Context, req *NewAccountRequest) (*NewAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewAccount not implemented")
+}
+func (*UnimplementedAdminServiceServer) UnlockAccount(ctx context.Context, req *UnlockAccountRequest) (*UnlockAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockAccount not implemented")
+}
+func (*UnimplementedAdminServiceServer) LockAccount(ctx context.Context, req *LockAccountRequest) (*LockAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockAccount not implemented")
+}
+func (*UnimplementedAdminServiceServer) ImportAccount(ctx context.Context, req *ImportAccountRequest) (*ImportAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportAccount not implemented")
+}
+func (*UnimplementedAdminServiceServer) ExportAccount(ctx context.Context, req *ExportAccountRequest) (*ExportAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportAccount not implemented")
+}
+func (*UnimplementedAdminServiceServer) SignHash(ctx context.Context, req *SignHashRequest) (*SignHashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignHash not implemented")
+}
+func (*UnimplementedAdminServiceServer) GetPeers(ctx context.Context, req *NonParamsRequest) (*PeersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeers not implemented")
+}
+func (*UnimplementedAdminServiceServer) AddPeer(ctx context.Context, req *AddPeerRequest) (*AddPeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPeer not implemented")
+}
+func (*UnimplementedAdminServiceServer) DisconnectPeer(ctx context.Context, req *DisconnectPeerRequest) (*DisconnectPeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisconnectPeer not implemented")
+}
+func (*UnimplementedAdminServiceServer) SetMining(ctx context.Context, req *SetMiningRequest) (*MiningStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMining not implemented")
+}
+func (*UnimplementedAdminServiceServer) GetMiningState(ctx context.Context, req *NonParamsRequest) (*MiningStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMiningState not implemented")
+}
+func (*UnimplementedAdminServiceServer) StartActiveSync(ctx context.Context, req *NonParamsRequest) (*StartActiveSyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartActiveSync not implemented")
+}
+func (*UnimplementedAdminServiceServer) SetHead(ctx context.Context, req *SetHeadRequest) (*SetHeadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHead not implemented")
+}
+func (*UnimplementedAdminServiceServer) CompactStorage(ctx context.Context, req *CompactStorageRequest) (*CompactStorageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompactStorage not implemented")
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_Accounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).Accounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/Accounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).Accounts(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_NewAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).NewAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/NewAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).NewAccount(ctx, req.(*NewAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_UnlockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).UnlockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/UnlockAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).UnlockAccount(ctx, req.(*UnlockAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_LockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).LockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/LockAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).LockAccount(ctx, req.(*LockAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ImportAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ImportAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ImportAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ImportAccount(ctx, req.(*ImportAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ExportAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ExportAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/ExportAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ExportAccount(ctx, req.(*ExportAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SignHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SignHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SignHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SignHash(ctx, req.(*SignHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/GetPeers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetPeers(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_AddPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AddPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/AddPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AddPeer(ctx, req.(*AddPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DisconnectPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisconnectPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DisconnectPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/DisconnectPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DisconnectPeer(ctx, req.(*DisconnectPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetMining_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMiningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetMining(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SetMining",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetMining(ctx, req.(*SetMiningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetMiningState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetMiningState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/GetMiningState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetMiningState(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_StartActiveSync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NonParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StartActiveSync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/StartActiveSync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StartActiveSync(ctx, req.(*NonParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetHead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetHead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/SetHead",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetHead(ctx, req.(*SetHeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_CompactStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompactStorageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).CompactStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcpb.AdminService/CompactStorage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).CompactStorage(ctx, req.(*CompactStorageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpcpb.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Accounts",
+			Handler:    _AdminService_Accounts_Handler,
+		},
+		{
+			MethodName: "NewAccount",
+			Handler:    _AdminService_NewAccount_Handler,
+		},
+		{
+			MethodName: "UnlockAccount",
+			Handler:    _AdminService_UnlockAccount_Handler,
+		},
+		{
+			MethodName: "LockAccount",
+			Handler:    _AdminService_LockAccount_Handler,
+		},
+		{
+			MethodName: "ImportAccount",
+			Handler:    _AdminService_ImportAccount_Handler,
+		},
+		{
+			MethodName: "ExportAccount",
+			Handler:    _AdminService_ExportAccount_Handler,
+		},
+		{
+			MethodName: "SignHash",
+			Handler:    _AdminService_SignHash_Handler,
+		},
+		{
+			MethodName: "GetPeers",
+			Handler:    _AdminService_GetPeers_Handler,
+		},
+		{
+			MethodName: "AddPeer",
+			Handler:    _AdminService_AddPeer_Handler,
+		},
+		{
+			MethodName: "DisconnectPeer",
+			Handler:    _AdminService_DisconnectPeer_Handler,
+		},
+		{
+			MethodName: "SetMining",
+			Handler:    _AdminService_SetMining_Handler,
+		},
+		{
+			MethodName: "GetMiningState",
+			Handler:    _AdminService_GetMiningState_Handler,
+		},
+		{
+			MethodName: "StartActiveSync",
+			Handler:    _AdminService_StartActiveSync_Handler,
+		},
+		{
+			MethodName: "SetHead",
+			Handler:    _AdminService_SetHead_Handler,
+		},
+		{
+			MethodName: "CompactStorage",
+			Handler:    _AdminService_CompactStorage_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "rpc.proto",
+}
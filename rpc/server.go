@@ -0,0 +1,257 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"gamc.pro/gamcio/go-gamc/core"
+	"gamc.pro/gamcio/go-gamc/network"
+	rpcpb "gamc.pro/gamcio/go-gamc/rpc/pb"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/util/config"
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"context"
+	"errors"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Errors
+var (
+	ErrEmptyRpcListen      = errors.New("rpc.rpc_listen should not be empty")
+	ErrHttpLimitsExceeded  = errors.New("too many concurrent http requests")
+	ErrRpcLimitsExceeded   = errors.New("too many concurrent rpc requests")
+	ErrInvalidHash         = errors.New("invalid hash")
+	ErrBlockNotFound       = errors.New("block not found")
+	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrNetServiceNotReady  = errors.New("net service is not ready")
+	ErrAdminNotLocal       = errors.New("admin service is only served on localhost or ipc")
+	ErrInvalidKey          = errors.New("invalid key")
+	ErrAdminCrossOrigin    = errors.New("admin service does not serve cross origin requests")
+)
+
+const (
+	shutdownTimeout    = 5 * time.Second
+	adminServicePrefix = "/rpcpb.AdminService/"
+)
+
+type gamc interface {
+	BlockChain() *core.BlockChain
+	NetService() network.Service
+	AccountManager() core.AccountManager
+	StorageMaintainer() *cdb.Maintainer
+	Config() *config.Config
+}
+
+// Server is the rpc server of the node, it serves gRPC on rpc_listen and
+// the HTTP gateway of the enabled modules on http_listen.
+type Server struct {
+	gamc        gamc
+	config      *RpcConfig
+	rpcServer   *grpc.Server
+	httpServers []*http.Server
+	cancel      context.CancelFunc
+	admin       *AdminService
+}
+
+// NewServer return new rpc Server.
+func NewServer(gamc gamc) *Server {
+	cfg := GetRpcConfig(gamc.Config())
+	limitUnary, limitStream := limitInterceptors(cfg.HttpLimits)
+	rpc := grpc.NewServer(
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(limitUnary, loggingUnaryInterceptor, adminUnaryInterceptor)),
+		grpc.StreamInterceptor(limitStream),
+	)
+
+	srv := &Server{
+		gamc:      gamc,
+		config:    cfg,
+		rpcServer: rpc,
+	}
+	srv.admin = &AdminService{server: srv}
+
+	rpcpb.RegisterApiServiceServer(rpc, &APIService{server: srv})
+	rpcpb.RegisterAdminServiceServer(rpc, srv.admin)
+	return srv
+}
+
+// Start start the gRPC server and the http gateway.
+func (s *Server) Start() error {
+	logging.CLog().Info("Starting RPC Server...")
+
+	if len(s.config.RpcListen) == 0 {
+		return ErrEmptyRpcListen
+	}
+
+	for _, addr := range s.config.RpcListen {
+		if err := s.serve("tcp", addr); err != nil {
+			s.rpcServer.Stop()
+			return err
+		}
+	}
+
+	if len(s.config.IpcPath) > 0 {
+		// remove the socket file left by an unclean shutdown.
+		os.Remove(s.config.IpcPath)
+		if err := s.serve("unix", s.config.IpcPath); err != nil {
+			s.rpcServer.Stop()
+			return err
+		}
+	}
+
+	if err := s.startGateway(); err != nil {
+		s.rpcServer.Stop()
+		return err
+	}
+
+	logging.CLog().WithFields(logrus.Fields{
+		"rpc_listen":  s.config.RpcListen,
+		"http_listen": s.config.HttpListen,
+		"http_module": s.config.HttpModule,
+		"ipc_path":    s.config.IpcPath,
+	}).Info("Started RPC Server.")
+	return nil
+}
+
+func (s *Server) serve(network, addr string) error {
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"listen": addr,
+			"err":    err,
+		}).Error("Failed to listen rpc address.")
+		return err
+	}
+
+	go func() {
+		if err := s.rpcServer.Serve(listener); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"listen": addr,
+				"err":    err,
+			}).Debug("RPC server stopped serving.")
+		}
+	}()
+	return nil
+}
+
+// Stop stop the http gateway and the gRPC server.
+func (s *Server) Stop() {
+	logging.CLog().Info("Stopping RPC Server...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	for _, srv := range s.httpServers {
+		if err := srv.Shutdown(ctx); err != nil {
+			logging.VLog().WithFields(logrus.Fields{
+				"listen": srv.Addr,
+				"err":    err,
+			}).Debug("Failed to shutdown http gateway.")
+		}
+	}
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.rpcServer.GracefulStop()
+
+	logging.CLog().Info("Stopped RPC Server.")
+}
+
+// RpcServer return the gRPC server, other services can be registered on it before Start.
+func (s *Server) RpcServer() *grpc.Server {
+	return s.rpcServer
+}
+
+// Config return the rpc config.
+func (s *Server) Config() *RpcConfig {
+	return s.config
+}
+
+func loggingUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"method": info.FullMethod,
+			"cost":   time.Since(start),
+			"err":    err,
+		}).Debug("RPC request failed.")
+	} else {
+		logging.VLog().WithFields(logrus.Fields{
+			"method": info.FullMethod,
+			"cost":   time.Since(start),
+		}).Debug("RPC request handled.")
+	}
+	return resp, err
+}
+
+// limitInterceptors returns the interceptors rejecting the requests and the
+// streams exceeding the limit of concurrent requests.
+func limitInterceptors(limits int) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	sem := make(chan struct{}, limits)
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		select {
+		case sem <- struct{}{}:
+			defer func() { <-sem }()
+			return handler(ctx, req)
+		default:
+			return nil, status.Error(codes.ResourceExhausted, ErrRpcLimitsExceeded.Error())
+		}
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		select {
+		case sem <- struct{}{}:
+			defer func() { <-sem }()
+			return handler(srv, ss)
+		default:
+			return status.Error(codes.ResourceExhausted, ErrRpcLimitsExceeded.Error())
+		}
+	}
+	return unary, stream
+}
+
+// adminUnaryInterceptor rejects the admin requests which are not from localhost or ipc.
+func adminUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if strings.HasPrefix(info.FullMethod, adminServicePrefix) {
+		p, ok := peer.FromContext(ctx)
+		if !ok || !isLocalAddr(p.Addr) {
+			return nil, status.Error(codes.PermissionDenied, ErrAdminNotLocal.Error())
+		}
+	}
+	return handler(ctx, req)
+}
+
+// isLocalAddr return if the address is a loopback or unix socket address.
+func isLocalAddr(addr net.Addr) bool {
+	switch addr := addr.(type) {
+	case *net.UnixAddr:
+		return true
+	case *net.TCPAddr:
+		return addr.IP.IsLoopback()
+	}
+	return false
+}
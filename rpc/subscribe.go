@@ -0,0 +1,221 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package rpc
+
+import (
+	"gamc.pro/gamcio/go-gamc/core"
+	rpcpb "gamc.pro/gamcio/go-gamc/rpc/pb"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TopicAddressTransaction the topic of the pending and packed transactions touching an address
+	TopicAddressTransaction = "chain.addressTransaction"
+
+	subscriberBufferSize = 1024
+)
+
+// Errors
+var (
+	ErrEmptyTopics         = errors.New("topics should not be empty")
+	ErrInvalidTopic        = errors.New("invalid topic")
+	ErrSubscriberTooSlow   = errors.New("subscriber is falling behind")
+	ErrEmptyAddressToWatch = errors.New("address is required by " + TopicAddressTransaction)
+)
+
+// subscription is the state of a Subscribe rpc.
+type subscription struct {
+	topics  map[string]bool
+	address *core.Address
+	stream  rpcpb.ApiService_SubscribeServer
+
+	// the height of the last new tail block sent to the client.
+	height uint64
+
+	// the new tail blocks up to replayed have been sent by the replay,
+	// it is reset by a reorg since the new branch has to be sent again.
+	replayed uint64
+}
+
+// Subscribe is the RPC API handler.
+func (s *APIService) Subscribe(req *rpcpb.SubscribeRequest, stream rpcpb.ApiService_SubscribeServer) error {
+	sub, err := newSubscription(req, stream)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	chain := s.server.gamc.BlockChain()
+	emitter := chain.EventEmitter()
+
+	// register before the replay, the events during the replay are buffered.
+	subscriber := core.NewEventSubscriber(subscriberBufferSize, sub.eventTopics())
+	emitter.Register(subscriber)
+	defer emitter.Deregister(subscriber)
+
+	if req.FromHeight > 0 {
+		tail := chain.TailBlock()
+		for height := req.FromHeight; height <= tail.Height(); height++ {
+			block := chain.GetBlockOnCanonicalChainByHeight(height)
+			if block == nil {
+				return status.Error(codes.NotFound, ErrBlockNotFound.Error())
+			}
+			if err := sub.onNewTailBlock(block); err != nil {
+				return err
+			}
+			if err := stream.Context().Err(); err != nil {
+				return err
+			}
+		}
+		sub.replayed = sub.height
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case e, ok := <-subscriber.EventChan():
+			if !ok {
+				return status.Error(codes.ResourceExhausted, fmt.Sprintf("%s, resume from height %d", ErrSubscriberTooSlow, sub.height+1))
+			}
+			if err := sub.onEvent(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func newSubscription(req *rpcpb.SubscribeRequest, stream rpcpb.ApiService_SubscribeServer) (*subscription, error) {
+	if len(req.Topics) == 0 {
+		return nil, ErrEmptyTopics
+	}
+
+	sub := &subscription{
+		topics: make(map[string]bool),
+		stream: stream,
+	}
+	for _, topic := range req.Topics {
+		switch topic {
+		case core.TopicNewTailBlock, core.TopicRevertBlock, core.TopicPendingTransaction:
+		case TopicAddressTransaction:
+			if len(req.Address) == 0 {
+				return nil, ErrEmptyAddressToWatch
+			}
+			addr, err := core.AddressParse(req.Address)
+			if err != nil {
+				return nil, err
+			}
+			sub.address = addr
+		default:
+			return nil, ErrInvalidTopic
+		}
+		sub.topics[topic] = true
+	}
+	return sub, nil
+}
+
+// eventTopics return the topics of the chain events the subscription is driven by.
+func (sub *subscription) eventTopics() []string {
+	topics := []string{core.TopicNewTailBlock, core.TopicRevertBlock}
+	if sub.topics[core.TopicPendingTransaction] || sub.topics[TopicAddressTransaction] {
+		topics = append(topics, core.TopicPendingTransaction)
+	}
+	return topics
+}
+
+func (sub *subscription) onEvent(e *core.Event) error {
+	switch e.Topic {
+	case core.TopicNewTailBlock:
+		if e.Block.Height() <= sub.replayed {
+			return nil
+		}
+		return sub.onNewTailBlock(e.Block)
+	case core.TopicRevertBlock:
+		sub.replayed = 0
+		if e.Block.Height() <= sub.height {
+			sub.height = e.Block.Height() - 1
+		}
+		if sub.topics[core.TopicRevertBlock] {
+			return sub.stream.Send(&rpcpb.SubscribeResponse{
+				Topic: core.TopicRevertBlock,
+				Block: toBlockResponse(e.Block, false),
+			})
+		}
+	case core.TopicPendingTransaction:
+		if sub.topics[core.TopicPendingTransaction] {
+			resp := toTransactionResponse(e.Transaction)
+			resp.Status = TxStatusPending
+			if err := sub.stream.Send(&rpcpb.SubscribeResponse{
+				Topic:       core.TopicPendingTransaction,
+				Transaction: resp,
+			}); err != nil {
+				return err
+			}
+		}
+		if sub.touched(e.Transaction) {
+			resp := toTransactionResponse(e.Transaction)
+			resp.Status = TxStatusPending
+			return sub.stream.Send(&rpcpb.SubscribeResponse{
+				Topic:       TopicAddressTransaction,
+				Transaction: resp,
+			})
+		}
+	}
+	return nil
+}
+
+func (sub *subscription) onNewTailBlock(block *core.Block) error {
+	if sub.topics[core.TopicNewTailBlock] {
+		if err := sub.stream.Send(&rpcpb.SubscribeResponse{
+			Topic: core.TopicNewTailBlock,
+			Block: toBlockResponse(block, false),
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, tx := range block.Transactions() {
+		if !sub.touched(tx) {
+			continue
+		}
+		resp := toTransactionResponse(tx)
+		resp.BlockHash = block.Hash().String()
+		resp.BlockHeight = block.Height()
+		resp.Status = TxStatusPacked
+		if err := sub.stream.Send(&rpcpb.SubscribeResponse{
+			Topic:       TopicAddressTransaction,
+			Transaction: resp,
+		}); err != nil {
+			return err
+		}
+	}
+
+	sub.height = block.Height()
+	return nil
+}
+
+// touched return if the tx is sent from or to the watched address.
+func (sub *subscription) touched(tx *core.Transaction) bool {
+	if sub.address == nil {
+		return false
+	}
+	return sub.address.Equals(tx.From()) || sub.address.Equals(tx.To())
+}
@@ -19,6 +19,10 @@ package cdb
 
 const IdealBatchSize = 100 * 1024 //
 
+// IdealBatchLen the number of writes of a batch always accepted by a storage,
+// along with IdealBatchSize.
+const IdealBatchLen = 1024
+
 //
 type Batcher interface {
 	ValueSize() int
@@ -26,5 +30,64 @@ type Batcher interface {
 	EnableBatch()
 	// DisableBatch disable batch write.
 	DisableBatch()
+	// Flush write the pending writes of the batch mode atomically. A storage
+	// bounding the size of its transactions fails with ErrBatchTooBig and
+	// keeps the writes pending.
 	Flush() error
 }
+
+// WriteBatch a set of writes applied atomically to a storage by Write,
+// independently of the batch mode of the storage. Nothing is visible before
+// Write, which leaves the batch unchanged. A storage bounding the size of its
+// transactions fails Write with ErrBatchTooBig, batches of at most
+// IdealBatchLen writes and IdealBatchSize bytes are always accepted.
+type WriteBatch interface {
+	Writer
+	// Len returns the number of writes.
+	Len() int
+	Write() error
+	Reset()
+}
+
+//
+type WriteBatcher interface {
+	NewWriteBatch() WriteBatch
+}
+
+// writeBatch a WriteBatch recording the writes for a storage applying them at once.
+type writeBatch struct {
+	opts  []*batchOpt
+	write func(opts []*batchOpt) error
+}
+
+func newWriteBatch(write func(opts []*batchOpt) error) *writeBatch {
+	return &writeBatch{write: write}
+}
+
+func (b *writeBatch) Put(key, value []byte) error {
+	b.opts = append(b.opts, &batchOpt{
+		key:   append([]byte{}, key...),
+		value: append([]byte{}, value...),
+	})
+	return nil
+}
+
+func (b *writeBatch) Delete(key []byte) error {
+	b.opts = append(b.opts, &batchOpt{
+		key:     append([]byte{}, key...),
+		deleted: true,
+	})
+	return nil
+}
+
+func (b *writeBatch) Len() int {
+	return len(b.opts)
+}
+
+func (b *writeBatch) Write() error {
+	return b.write(b.opts)
+}
+
+func (b *writeBatch) Reset() {
+	b.opts = nil
+}
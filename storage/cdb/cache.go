@@ -0,0 +1,332 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package cdb
+
+import (
+	"gamc.pro/gamcio/go-gamc/metrics"
+	"container/list"
+	gometrics "github.com/rcrowley/go-metrics"
+	"sync"
+)
+
+// cacheEntry a value read from the storage.
+type cacheEntry struct {
+	key   string
+	value []byte
+}
+
+func (e *cacheEntry) size() int {
+	return len(e.key) + len(e.value)
+}
+
+// keyRange the keys in [start, limit).
+type keyRange struct {
+	start, limit []byte
+}
+
+// CachedStorage a read-through LRU cache in front of a Storage, bounded by the
+// bytes of the cached keys and values.
+//
+// Writes invalidate the cached values of their keys. While the storage has
+// pending batch writes, the written keys are not cached, so that the reads
+// return what the storage returns, the values before the batch. They are
+// cached again once the storage reports an empty batch, whether it was
+// flushed or discarded through the cache or not.
+//
+// The cached values are shared with the callers, which must not modify them.
+type CachedStorage struct {
+	db       Storage
+	capacity int
+	size     int
+	entries  map[string]*list.Element
+	lru      *list.List
+
+	// the keys written while the storage has pending batch writes.
+	pending       map[string]bool
+	pendingRanges []keyRange
+
+	// version is increased by each write, so that a value read from the
+	// storage during a write is not cached.
+	version uint64
+	mutex   sync.Mutex
+
+	hits   gometrics.Counter
+	misses gometrics.Counter
+	bytes  gometrics.Gauge
+}
+
+// NewCachedStorage returns a cache of capacity bytes in front of db, its hits
+// and misses are exported as the gamc.db.cache.<name>.hit and .miss metrics.
+func NewCachedStorage(db Storage, name string, capacity int) *CachedStorage {
+	prefix := "gamc.db.cache." + name + "."
+	return &CachedStorage{
+		db:       db,
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		pending:  make(map[string]bool),
+		hits:     metrics.NewCounter(prefix + "hit"),
+		misses:   metrics.NewCounter(prefix + "miss"),
+		bytes:    metrics.NewGauge(prefix + "size"),
+	}
+}
+
+// lookup returns the cached value of key and marks it as recently used.
+func (c *CachedStorage) lookup(key string) (*cacheEntry, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*cacheEntry), true
+}
+
+func (c *CachedStorage) remove(key string) {
+	if elem, ok := c.entries[key]; ok {
+		c.size -= elem.Value.(*cacheEntry).size()
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// add cache an entry, evicting the least recently used entries to make room for it.
+func (c *CachedStorage) add(entry *cacheEntry) {
+	if entry.size() > c.capacity || c.isPending([]byte(entry.key)) {
+		return
+	}
+	c.remove(entry.key)
+	for c.size+entry.size() > c.capacity {
+		c.remove(c.lru.Back().Value.(*cacheEntry).key)
+	}
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	c.size += entry.size()
+	c.bytes.Update(int64(c.size))
+}
+
+func (c *CachedStorage) isPending(key []byte) bool {
+	if c.pending[string(key)] {
+		return true
+	}
+	for _, r := range c.pendingRanges {
+		if KeyInRange(key, r.start, r.limit) {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidate drop the cached value of key, and keep it out of the cache while
+// the write is pending.
+func (c *CachedStorage) invalidate(key []byte) {
+	c.version++
+	c.remove(string(key))
+	if c.db.ValueSize() > 0 {
+		c.pending[string(key)] = true
+	}
+	c.release()
+}
+
+// release allow the pending keys in the cache again once the storage has no
+// pending batch writes.
+func (c *CachedStorage) release() {
+	if len(c.pending) == 0 && len(c.pendingRanges) == 0 {
+		return
+	}
+	if c.db.ValueSize() > 0 {
+		return
+	}
+	c.version++
+	c.pending = make(map[string]bool)
+	c.pendingRanges = nil
+}
+
+func (c *CachedStorage) Has(key []byte) (bool, error) {
+	c.mutex.Lock()
+	_, ok := c.lookup(string(key))
+	c.mutex.Unlock()
+
+	if ok {
+		c.hits.Inc(1)
+		return true, nil
+	}
+	c.misses.Inc(1)
+	return c.db.Has(key)
+}
+
+func (c *CachedStorage) Get(key []byte) ([]byte, error) {
+	c.mutex.Lock()
+	entry, ok := c.lookup(string(key))
+	c.release()
+	version := c.version
+	c.mutex.Unlock()
+
+	if ok {
+		c.hits.Inc(1)
+		return entry.value, nil
+	}
+	c.misses.Inc(1)
+
+	value, err := c.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.version == version {
+		c.add(&cacheEntry{key: string(key), value: value})
+	}
+	return value, nil
+}
+
+func (c *CachedStorage) Put(key, value []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.db.Put(key, value); err != nil {
+		return err
+	}
+	c.invalidate(key)
+	return nil
+}
+
+func (c *CachedStorage) Delete(key []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.db.Delete(key); err != nil {
+		return err
+	}
+	c.invalidate(key)
+	return nil
+}
+
+func (c *CachedStorage) DeleteRange(start []byte, limit []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.db.DeleteRange(start, limit); err != nil {
+		return err
+	}
+	c.version++
+	for key := range c.entries {
+		if KeyInRange([]byte(key), start, limit) {
+			c.remove(key)
+		}
+	}
+	if c.db.ValueSize() > 0 {
+		c.pendingRanges = append(c.pendingRanges, keyRange{
+			start: append([]byte{}, start...),
+			limit: append([]byte(nil), limit...),
+		})
+	}
+	c.release()
+	return nil
+}
+
+// NewWriteBatch returns a batch of the storage invalidating the cached values
+// of its keys once written.
+func (c *CachedStorage) NewWriteBatch() WriteBatch {
+	return &cacheBatch{WriteBatch: c.db.NewWriteBatch(), cache: c}
+}
+
+// Invalidate drop the cached values of keys, written to the storage without
+// going through the cache, like in a batch shared with other tables.
+func (c *CachedStorage) Invalidate(keys ...[]byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.version++
+	for _, key := range keys {
+		c.remove(string(key))
+	}
+}
+
+func (c *CachedStorage) NewIterator() Iterator {
+	return c.db.NewIterator()
+}
+
+func (c *CachedStorage) NewIteratorWithPrefix(prefix []byte) Iterator {
+	return c.db.NewIteratorWithPrefix(prefix)
+}
+
+func (c *CachedStorage) NewIteratorWithRange(start []byte, limit []byte) Iterator {
+	return c.db.NewIteratorWithRange(start, limit)
+}
+
+// Close does nothing, the storage is closed by its owner.
+func (c *CachedStorage) Close() error {
+	return nil
+}
+
+func (c *CachedStorage) ValueSize() int {
+	return c.db.ValueSize()
+}
+
+func (c *CachedStorage) EnableBatch() {
+	c.db.EnableBatch()
+}
+
+func (c *CachedStorage) DisableBatch() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.db.DisableBatch()
+	c.release()
+}
+
+func (c *CachedStorage) Flush() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.db.Flush(); err != nil {
+		return err
+	}
+	c.release()
+	return nil
+}
+
+// cacheBatch record the keys of a batch to invalidate them once written.
+type cacheBatch struct {
+	WriteBatch
+	cache *CachedStorage
+	keys  [][]byte
+}
+
+func (b *cacheBatch) Put(key, value []byte) error {
+	b.keys = append(b.keys, append([]byte{}, key...))
+	return b.WriteBatch.Put(key, value)
+}
+
+func (b *cacheBatch) Delete(key []byte) error {
+	b.keys = append(b.keys, append([]byte{}, key...))
+	return b.WriteBatch.Delete(key)
+}
+
+func (b *cacheBatch) Write() error {
+	if err := b.WriteBatch.Write(); err != nil {
+		return err
+	}
+	b.cache.Invalidate(b.keys...)
+	return nil
+}
+
+func (b *cacheBatch) Reset() {
+	b.WriteBatch.Reset()
+	b.keys = nil
+}
@@ -0,0 +1,366 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package cdb
+
+import (
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"fmt"
+	"github.com/dgraph-io/badger"
+	"strings"
+	"sync"
+)
+
+// Badger properties for Stat.
+const (
+	BadgerPropertyLSMSize  = "badger.lsmsize"
+	BadgerPropertyVlogSize = "badger.vlogsize"
+	BadgerPropertyTables   = "badger.tables"
+)
+
+// badgerGCDiscardRatio the ratio of stale data a value log file must hold to be rewritten.
+const badgerGCDiscardRatio = 0.5
+
+// badgerMinTableSize the smallest table size, so that the transactions of
+// Badger, bounded by 15% of it, hold a batch of IdealBatchLen writes and
+// IdealBatchSize bytes.
+const badgerMinTableSize = 4 << 20
+
+// BadgerDB a Storage on Badger, a pure Go LSM tree keeping the values in a
+// separate log, which cuts the write amplification of the compactions.
+type BadgerDB struct {
+	db          *badger.DB
+	enableBatch bool
+	filename    string
+	mutex       sync.Mutex
+	batchOpts   map[string]*batchOpt
+}
+
+// NewBadgerDB open the Badger database in the DbDir of dbcfg. The tables are
+// memory mapped, the WriteBuffer of dbcfg is the size of the memtables, at
+// least badgerMinTableSize.
+func NewBadgerDB(dbcfg *DbConfig) (*BadgerDB, error) {
+	opts := badger.DefaultOptions(dbcfg.DbDir).WithLogger(logging.VLog())
+	if dbcfg.WriteBuffer > 0 {
+		size := int64(dbcfg.WriteBuffer) << 20
+		if size < badgerMinTableSize {
+			size = badgerMinTableSize
+		}
+		opts = opts.WithMaxTableSize(size)
+	}
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BadgerDB{
+		db:          db,
+		enableBatch: dbcfg.EnableBatch,
+		filename:    dbcfg.DbDir,
+		batchOpts:   make(map[string]*batchOpt),
+	}, nil
+}
+
+func (db *BadgerDB) Close() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return db.db.Close()
+}
+
+func (db *BadgerDB) Has(key []byte) (bool, error) {
+	_, err := db.Get(key)
+	if err == ErrKeyNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (db *BadgerDB) Get(key []byte) ([]byte, error) {
+	var value []byte
+	err := db.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return nil, ErrKeyNotFound
+	}
+	return value, err
+}
+
+func (db *BadgerDB) Put(key, value []byte) error {
+	if db.enableBatch {
+		db.mutex.Lock()
+		defer db.mutex.Unlock()
+
+		db.batchOpts[byteutils.Hex(key)] = &batchOpt{
+			key:     key,
+			value:   value,
+			deleted: false,
+		}
+
+		return nil
+	}
+	return db.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+func (db *BadgerDB) Delete(key []byte) error {
+	if db.enableBatch {
+		db.mutex.Lock()
+		defer db.mutex.Unlock()
+
+		db.batchOpts[byteutils.Hex(key)] = &batchOpt{
+			key:     key,
+			deleted: true,
+		}
+
+		return nil
+	}
+	return db.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+// DeleteRange delete the keys in [start, limit), in a write batch split in as
+// many transactions as Badger needs, so a failure may leave part of the range.
+func (db *BadgerDB) DeleteRange(start []byte, limit []byte) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	var keys [][]byte
+	if err := db.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Seek(start); it.Valid() && KeyInRange(it.Item().Key(), start, limit); it.Next() {
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if db.enableBatch {
+		for _, key := range keys {
+			db.batchOpts[byteutils.Hex(key)] = &batchOpt{
+				key:     key,
+				deleted: true,
+			}
+		}
+		markRangeDeleted(db.batchOpts, start, limit)
+		return nil
+	}
+
+	wb := db.db.NewWriteBatch()
+	defer wb.Cancel()
+	for _, key := range keys {
+		if err := wb.Delete(key); err != nil {
+			return err
+		}
+	}
+	return wb.Flush()
+}
+
+func (db *BadgerDB) EnableBatch() {
+	db.enableBatch = true
+}
+
+func (db *BadgerDB) DisableBatch() {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.batchOpts = make(map[string]*batchOpt)
+	db.enableBatch = false
+}
+
+//
+func (db *BadgerDB) ValueSize() int {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return len(db.batchOpts)
+}
+
+// Flush write the pending batch in a single transaction. A batch over the
+// bound of Badger, 15% of the table size, fails with ErrBatchTooBig and stays
+// pending.
+func (db *BadgerDB) Flush() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if !db.enableBatch {
+		return nil
+	}
+	opts := make([]*batchOpt, 0, len(db.batchOpts))
+	for _, opt := range db.batchOpts {
+		opts = append(opts, opt)
+	}
+	if err := db.update(opts); err != nil {
+		return err
+	}
+	db.batchOpts = make(map[string]*batchOpt)
+	return nil
+}
+
+// NewWriteBatch returns a batch applied in a single transaction, which fails
+// with ErrBatchTooBig if the batch exceeds the bound of Badger.
+func (db *BadgerDB) NewWriteBatch() WriteBatch {
+	return newWriteBatch(db.update)
+}
+
+// update apply opts in a single transaction.
+func (db *BadgerDB) update(opts []*batchOpt) error {
+	err := db.db.Update(func(txn *badger.Txn) error {
+		for _, opt := range opts {
+			var err error
+			if opt.deleted {
+				err = txn.Delete(opt.key)
+			} else {
+				err = txn.Set(opt.key, opt.value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == badger.ErrTxnTooBig {
+		return ErrBatchTooBig
+	}
+	return err
+}
+
+func (db *BadgerDB) NewIterator() Iterator {
+	return db.NewIteratorWithRange(nil, nil)
+}
+
+// NewIteratorWithPrefix iterate the keys with prefix in order, the iterator
+// holds a read transaction until it's released.
+func (db *BadgerDB) NewIteratorWithPrefix(prefix []byte) Iterator {
+	return db.NewIteratorWithRange(BytesPrefix(prefix))
+}
+
+// NewIteratorWithRange iterate the keys in [start, limit) in order, the iterator
+// holds a read transaction until it's released.
+func (db *BadgerDB) NewIteratorWithRange(start []byte, limit []byte) Iterator {
+	txn := db.db.NewTransaction(false)
+	return &badgerIterator{
+		txn:   txn,
+		it:    txn.NewIterator(badger.DefaultIteratorOptions),
+		start: start,
+		limit: limit,
+	}
+}
+
+// Stat returns the size in bytes of the LSM tree or of the value log, or the
+// number of tables by level.
+func (db *BadgerDB) Stat(property string) (string, error) {
+	switch property {
+	case BadgerPropertyLSMSize:
+		lsm, _ := db.db.Size()
+		return fmt.Sprint(lsm), nil
+	case BadgerPropertyVlogSize:
+		_, vlog := db.db.Size()
+		return fmt.Sprint(vlog), nil
+	case BadgerPropertyTables:
+		var levels []int
+		for _, table := range db.db.Tables(false) {
+			for len(levels) <= table.Level {
+				levels = append(levels, 0)
+			}
+			levels[table.Level]++
+		}
+		stats := make([]string, len(levels))
+		for level, count := range levels {
+			stats[level] = fmt.Sprintf("L%d:%d", level, count)
+		}
+		return strings.Join(stats, " "), nil
+	}
+	return "", ErrUnsupportedProperty
+}
+
+// Compact flatten the LSM tree and garbage collect the value log. Badger can't
+// compact a key range, the whole database is compacted whatever the range.
+func (db *BadgerDB) Compact(start []byte, limit []byte) error {
+	if err := db.db.Flatten(1); err != nil {
+		return err
+	}
+	for {
+		if err := db.db.RunValueLogGC(badgerGCDiscardRatio); err != nil {
+			if err == badger.ErrNoRewrite {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// badgerIterator iterate a read transaction.
+type badgerIterator struct {
+	txn          *badger.Txn
+	it           *badger.Iterator
+	start, limit []byte
+	key, value   []byte
+	started      bool
+	done         bool
+	err          error
+}
+
+func (it *badgerIterator) Next() bool {
+	if it.it == nil || it.done {
+		return false
+	}
+	if it.started {
+		it.it.Next()
+	} else {
+		it.it.Seek(it.start)
+		it.started = true
+	}
+	if !it.it.Valid() || !KeyInRange(it.it.Item().Key(), it.start, it.limit) {
+		it.key, it.value = nil, nil
+		it.done = true
+		return false
+	}
+
+	item := it.it.Item()
+	it.key = item.KeyCopy(it.key[:0])
+	if it.value, it.err = item.ValueCopy(it.value[:0]); it.err != nil {
+		it.key, it.value = nil, nil
+		it.done = true
+		return false
+	}
+	return true
+}
+
+func (it *badgerIterator) Error() error  { return it.err }
+func (it *badgerIterator) Key() []byte   { return it.key }
+func (it *badgerIterator) Value() []byte { return it.value }
+
+func (it *badgerIterator) Release() {
+	if it.it != nil {
+		it.it.Close()
+		it.txn.Discard()
+		it.it = nil
+	}
+	it.key, it.value = nil, nil
+}
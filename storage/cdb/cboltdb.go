@@ -18,103 +18,290 @@
 package cdb
 
 import (
-	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
 	"github.com/boltdb/bolt"
 )
 
-var (
-	ErrBucketNotExist = errors.New("bucket does not exist")
-	ErrKeyNotExist    = errors.New("key does not exist")
-)
+// boltBucket the bucket holding all the keys of the storage.
+var boltBucket = []byte("gamc")
 
-// gamcDB is a persistent key-value store.
-type gamcDB struct {
-	fn string   // db filename
-	db *bolt.DB // db
+// BoltDB a Storage kept in a single BoltDB file.
+type BoltDB struct {
+	db          *bolt.DB
+	enableBatch bool
+	filename    string
+	mutex       sync.Mutex
+	batchOpts   map[string]*batchOpt
 }
 
-//
-func NewgamcDB(filename string) (*gamcDB, error) {
-	db, err := bolt.Open(filename, 0600, nil)
+// NewBoltDB open the BoltDB file at the DbDir of dbcfg, creating it if needed.
+func NewBoltDB(dbcfg *DbConfig) (*BoltDB, error) {
+	if err := os.MkdirAll(filepath.Dir(dbcfg.DbDir), 0700); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(dbcfg.DbDir, 0600, &bolt.Options{Timeout: time.Second})
 	if err != nil {
 		return nil, err
 	}
-	cdb := &gamcDB{
-		fn: filename,
-		db: db,
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, err
 	}
-	return cdb, nil
+
+	return &BoltDB{
+		db:          db,
+		enableBatch: dbcfg.EnableBatch,
+		filename:    dbcfg.DbDir,
+		batchOpts:   make(map[string]*batchOpt),
+	}, nil
 }
 
-//
-func (c *gamcDB) Has(bucket, key []byte) bool {
-	if err := c.db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket(bucket)
-		if b == nil {
-			return ErrBucketNotExist
+func (db *BoltDB) Close() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return db.db.Close()
+}
+
+func (db *BoltDB) Has(key []byte) (bool, error) {
+	_, err := db.Get(key)
+	if err == ErrKeyNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (db *BoltDB) Get(key []byte) ([]byte, error) {
+	var value []byte
+	err := db.db.View(func(tx *bolt.Tx) error {
+		// the value is only valid during the transaction.
+		if data := tx.Bucket(boltBucket).Get(key); data != nil {
+			value = append([]byte{}, data...)
+			return nil
 		}
-		if data := b.Get(key); data == nil {
-			return ErrKeyNotExist
+		return ErrKeyNotFound
+	})
+	return value, err
+}
+
+func (db *BoltDB) Put(key, value []byte) error {
+	if db.enableBatch {
+		db.mutex.Lock()
+		defer db.mutex.Unlock()
+
+		db.batchOpts[byteutils.Hex(key)] = &batchOpt{
+			key:     key,
+			value:   value,
+			deleted: false,
 		}
+
 		return nil
-	}); err != nil {
-		return false
 	}
-	return true
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Put(key, value)
+	})
 }
 
-//
-func (c *gamcDB) Get(bucket, key []byte) []byte {
-	var data []byte
-	if err := c.db.View(func(tx *bolt.Tx) error {
-		b := tx.Bucket(bucket)
-		if b == nil {
-			return ErrBucketNotExist
-		}
-		if data = b.Get(key); data == nil {
-			return ErrKeyNotExist
+func (db *BoltDB) Delete(key []byte) error {
+	if db.enableBatch {
+		db.mutex.Lock()
+		defer db.mutex.Unlock()
+
+		db.batchOpts[byteutils.Hex(key)] = &batchOpt{
+			key:     key,
+			deleted: true,
 		}
-		return nil
-	}); err != nil {
+
 		return nil
 	}
-	return data
+	return db.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Delete(key)
+	})
+}
+
+func (db *BoltDB) EnableBatch() {
+	db.enableBatch = true
+}
+
+func (db *BoltDB) DisableBatch() {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.batchOpts = make(map[string]*batchOpt)
+	db.enableBatch = false
 }
 
 //
-func (c *gamcDB) Put(bucket, key, value []byte) error {
-	if err := c.db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket(bucket)
-		if b == nil {
-			if newBucket, err := tx.CreateBucket(bucket); err == nil {
-				return err
+func (db *BoltDB) ValueSize() int {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return len(db.batchOpts)
+}
+
+// Flush write the pending batch in a single transaction.
+func (db *BoltDB) Flush() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if !db.enableBatch {
+		return nil
+	}
+	opts := db.batchOpts
+	db.batchOpts = make(map[string]*batchOpt)
+
+	return db.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		for _, opt := range opts {
+			var err error
+			if opt.deleted {
+				err = bucket.Delete(opt.key)
 			} else {
-				b = newBucket
+				err = bucket.Put(opt.key, opt.value)
+			}
+			if err != nil {
+				return err
 			}
-		}
-		if err := b.Put(key, value); err != nil {
-			return err
 		}
 		return nil
-	}); err != nil {
-		return err
+	})
+}
+
+// NewWriteBatch returns a batch applied in a single transaction.
+func (db *BoltDB) NewWriteBatch() WriteBatch {
+	return newWriteBatch(func(opts []*batchOpt) error {
+		return db.db.Update(func(tx *bolt.Tx) error {
+			bucket := tx.Bucket(boltBucket)
+			for _, opt := range opts {
+				var err error
+				if opt.deleted {
+					err = bucket.Delete(opt.key)
+				} else {
+					err = bucket.Put(opt.key, opt.value)
+				}
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func (db *BoltDB) NewIterator() Iterator {
+	return db.NewIteratorWithPrefix(nil)
+}
+
+// NewIteratorWithPrefix iterate the keys with prefix in order, the iterator
+// holds a read transaction until it's released.
+func (db *BoltDB) NewIteratorWithPrefix(prefix []byte) Iterator {
+	return db.NewIteratorWithRange(BytesPrefix(prefix))
+}
+
+// NewIteratorWithRange iterate the keys in [start, limit) in order, the iterator
+// holds a read transaction until it's released.
+func (db *BoltDB) NewIteratorWithRange(start []byte, limit []byte) Iterator {
+	tx, err := db.db.Begin(false)
+	if err != nil {
+		return &boltIterator{err: err}
+	}
+	return &boltIterator{
+		tx:     tx,
+		cursor: tx.Bucket(boltBucket).Cursor(),
+		start:  start,
+		limit:  limit,
 	}
-	return nil
 }
 
-//
-func (c *gamcDB) Delete(bucket, key []byte) error {
-	if err := c.db.Update(func(tx *bolt.Tx) error {
-		b := tx.Bucket(bucket)
-		if b == nil {
-			return ErrBucketNotExist
-		}
-		if err := b.Delete(key); err != nil {
+// DeleteRange delete the keys in [start, limit) in a single transaction.
+func (db *BoltDB) DeleteRange(start []byte, limit []byte) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.enableBatch {
+		if err := db.db.View(func(tx *bolt.Tx) error {
+			boltRange(tx, start, limit, func(key []byte) {
+				key = append([]byte{}, key...)
+				db.batchOpts[byteutils.Hex(key)] = &batchOpt{
+					key:     key,
+					deleted: true,
+				}
+			})
+			return nil
+		}); err != nil {
 			return err
 		}
+		markRangeDeleted(db.batchOpts, start, limit)
 		return nil
-	}); err != nil {
-		return err
 	}
-	return nil
+
+	return db.db.Update(func(tx *bolt.Tx) error {
+		// a cursor can't keep its position while the bucket is modified.
+		var keys [][]byte
+		boltRange(tx, start, limit, func(key []byte) {
+			keys = append(keys, key)
+		})
+		bucket := tx.Bucket(boltBucket)
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// boltRange call fn with every key in [start, limit).
+func boltRange(tx *bolt.Tx, start []byte, limit []byte, fn func(key []byte)) {
+	cursor := tx.Bucket(boltBucket).Cursor()
+	for key, _ := cursor.Seek(start); key != nil && KeyInRange(key, start, limit); key, _ = cursor.Next() {
+		fn(key)
+	}
+}
+
+// boltIterator iterate a bucket with a cursor.
+type boltIterator struct {
+	tx           *bolt.Tx
+	cursor       *bolt.Cursor
+	start, limit []byte
+	key, value   []byte
+	started      bool
+	err          error
+}
+
+func (it *boltIterator) Next() bool {
+	if it.cursor == nil {
+		return false
+	}
+	if it.started {
+		it.key, it.value = it.cursor.Next()
+	} else {
+		it.key, it.value = it.cursor.Seek(it.start)
+		it.started = true
+	}
+	if it.key == nil || !KeyInRange(it.key, it.start, it.limit) {
+		it.key, it.value = nil, nil
+		it.cursor = nil
+		return false
+	}
+	return true
+}
+
+func (it *boltIterator) Error() error  { return it.err }
+func (it *boltIterator) Key() []byte   { return it.key }
+func (it *boltIterator) Value() []byte { return it.value }
+
+func (it *boltIterator) Release() {
+	if it.tx != nil {
+		it.tx.Rollback()
+		it.tx = nil
+	}
+	it.cursor = nil
+	it.key, it.value = nil, nil
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/syndtr/goleveldb/leveldb/util"
 	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -33,6 +34,11 @@ const (
 )
 
 type LevelDB struct {
+	// the bytes read and written by the callers, first for the 64-bit
+	// alignment of the atomic operations.
+	userRead  uint64
+	userWrite uint64
+
 	ldb         *leveldb.DB
 	batch       *leveldb.Batch
 	enableBatch bool
@@ -55,10 +61,15 @@ func NewLevelDB(dbcfg *DbConfig, cache int, handles int) (*LevelDB, error) {
 		handles = minHandles
 	}
 
+	writeBuffer := dbcfg.WriteBuffer
+	if writeBuffer <= 0 {
+		writeBuffer = cache / 4
+	}
+
 	db, err := leveldb.OpenFile(dbcfg.DbDir, &opt.Options{
 		OpenFilesCacheCapacity: handles,
 		BlockCacheCapacity:     cache / 2 * opt.MiB,
-		WriteBuffer:            cache / 4 * opt.MiB, // Two of these are used internally
+		WriteBuffer:            writeBuffer * opt.MiB, // Two of these are used internally
 		Filter:                 filter.NewBloomFilter(10),
 	})
 
@@ -101,6 +112,7 @@ func (db *LevelDB) Get(key []byte) ([]byte, error) {
 	if err != nil && err == leveldb.ErrNotFound {
 		return nil, ErrKeyNotFound
 	}
+	atomic.AddUint64(&db.userRead, uint64(len(value)))
 	return value, err
 }
 
@@ -117,6 +129,7 @@ func (db *LevelDB) Put(key, value []byte) error {
 
 		return nil
 	}
+	atomic.AddUint64(&db.userWrite, uint64(len(key)+len(value)))
 	return db.ldb.Put(key, value, nil)
 }
 
@@ -133,9 +146,40 @@ func (db *LevelDB) Delete(key []byte) error {
 		return nil
 	}
 
+	atomic.AddUint64(&db.userWrite, uint64(len(key)))
 	return db.ldb.Delete(key, nil)
 }
 
+func (db *LevelDB) DeleteRange(start []byte, limit []byte) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	it := db.ldb.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+	defer it.Release()
+
+	batch := new(leveldb.Batch)
+	for it.Next() {
+		key := append([]byte{}, it.Key()...)
+		if db.enableBatch {
+			db.batchOpts[byteutils.Hex(key)] = &batchOpt{
+				key:     key,
+				deleted: true,
+			}
+		} else {
+			batch.Delete(key)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if db.enableBatch {
+		markRangeDeleted(db.batchOpts, start, limit)
+		return nil
+	}
+	return db.write(batch)
+}
+
 func (db *LevelDB) EnableBatch() {
 	db.enableBatch = true
 }
@@ -150,10 +194,9 @@ func (db *LevelDB) DisableBatch() {
 
 //
 func (db *LevelDB) ValueSize() int {
-	if db.batch == nil {
-		return 0
-	}
-	return db.batch.Len()
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return len(db.batchOpts)
 }
 
 func (db *LevelDB) Flush() error {
@@ -174,9 +217,19 @@ func (db *LevelDB) Flush() error {
 	}
 	db.batchOpts = make(map[string]*batchOpt)
 
+	return db.write(batch)
+}
+
+func (db *LevelDB) write(batch *leveldb.Batch) error {
+	atomic.AddUint64(&db.userWrite, uint64(len(batch.Dump())))
 	return db.ldb.Write(batch, nil)
 }
 
+// NewWriteBatch returns a leveldb batch, written atomically.
+func (db *LevelDB) NewWriteBatch() WriteBatch {
+	return &levelBatch{db: db, batch: new(leveldb.Batch)}
+}
+
 func (db *LevelDB) NewIterator() Iterator {
 	return db.NewIteratorWithPrefix(nil)
 }
@@ -185,6 +238,75 @@ func (db *LevelDB) NewIteratorWithPrefix(prefix []byte) Iterator {
 	return db.ldb.NewIterator(util.BytesPrefix(prefix), nil)
 }
 
+func (db *LevelDB) NewIteratorWithRange(start []byte, limit []byte) Iterator {
+	return db.ldb.NewIterator(&util.Range{Start: start, Limit: limit}, nil)
+}
+
 func (db *LevelDB) Compact(start []byte, limit []byte) error {
 	return db.ldb.CompactRange(util.Range{Start: start, Limit: limit})
 }
+
+// Stat returns the value of a leveldb property, like leveldb.stats,
+// leveldb.iostats or leveldb.num-files-at-level0.
+func (db *LevelDB) Stat(property string) (string, error) {
+	value, err := db.ldb.GetProperty(property)
+	if err == leveldb.ErrNotFound {
+		return "", ErrUnsupportedProperty
+	}
+	return value, err
+}
+
+// Stats returns the statistics of the levels and the io of the database.
+func (db *LevelDB) Stats() (*Stats, error) {
+	dbStats := new(leveldb.DBStats)
+	if err := db.ldb.Stats(dbStats); err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{
+		Levels:     make([]LevelStats, len(dbStats.LevelSizes)),
+		IORead:     dbStats.IORead,
+		IOWrite:    dbStats.IOWrite,
+		UserRead:   atomic.LoadUint64(&db.userRead),
+		UserWrite:  atomic.LoadUint64(&db.userWrite),
+		WriteDelay: dbStats.WriteDelayDuration,
+	}
+	for i := range stats.Levels {
+		stats.Levels[i] = LevelStats{
+			Tables:         dbStats.LevelTablesCounts[i],
+			Size:           dbStats.LevelSizes[i],
+			Read:           dbStats.LevelRead[i],
+			Write:          dbStats.LevelWrite[i],
+			CompactionTime: dbStats.LevelDurations[i],
+		}
+	}
+	return stats, nil
+}
+
+// levelBatch a WriteBatch of a LevelDB.
+type levelBatch struct {
+	db    *LevelDB
+	batch *leveldb.Batch
+}
+
+func (b *levelBatch) Put(key, value []byte) error {
+	b.batch.Put(key, value)
+	return nil
+}
+
+func (b *levelBatch) Delete(key []byte) error {
+	b.batch.Delete(key)
+	return nil
+}
+
+func (b *levelBatch) Len() int {
+	return b.batch.Len()
+}
+
+func (b *levelBatch) Write() error {
+	return b.db.write(b.batch)
+}
+
+func (b *levelBatch) Reset() {
+	b.batch.Reset()
+}
@@ -15,75 +15,128 @@
 // You should have received a copy of the GNU General Public License
 // along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
 //
-
 package cdb
 
 import (
-	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"bytes"
+	"sort"
 	"sync"
 )
 
 // MemoryDB the nodes in trie.
 type MemoryDB struct {
-	data *sync.Map
-}
-
-// kv entry
-type kv struct{ k, v []byte }
-
-// MemoryBatch do batch task in memory storage
-type MemoryBatch struct {
-	db      *MemoryDB
-	entries []*kv
+	data        map[string][]byte
+	enableBatch bool
+	batchOpts   map[string]*batchOpt
+	mutex       sync.RWMutex
 }
 
 // NewMemoryStorage init a storage
 func NewMemoryStorage() (*MemoryDB, error) {
 	return &MemoryDB{
-		data: new(sync.Map),
+		data:      make(map[string][]byte),
+		batchOpts: make(map[string]*batchOpt),
 	}, nil
 }
 
 // Get return value to the key in Storage
 func (db *MemoryDB) Get(key []byte) ([]byte, error) {
-	if entry, ok := db.data.Load(byteutils.Hex(key)); ok {
-		return entry.([]byte), nil
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	if value, ok := db.data[string(key)]; ok {
+		return value, nil
 	}
 	return nil, ErrKeyNotFound
 }
 
 // Put put the key-value entry to Storage
 func (db *MemoryDB) Put(key []byte, value []byte) error {
-	db.data.Store(byteutils.Hex(key), value)
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	value = append([]byte{}, value...)
+	if db.enableBatch {
+		db.batchOpts[string(key)] = &batchOpt{
+			key:     key,
+			value:   value,
+			deleted: false,
+		}
+		return nil
+	}
+	db.data[string(key)] = value
 	return nil
 }
 
 // Del delete the key in Storage.
 func (db *MemoryDB) Del(key []byte) error {
-	db.data.Delete(byteutils.Hex(key))
-	return nil
+	return db.Delete(key)
 }
 
 // EnableBatch enable batch write.
 func (db *MemoryDB) EnableBatch() {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.enableBatch = true
 }
 
 // Flush write and flush pending batch write.
 func (db *MemoryDB) Flush() error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if !db.enableBatch {
+		return nil
+	}
+	for key, opt := range db.batchOpts {
+		if opt.deleted {
+			delete(db.data, key)
+		} else {
+			db.data[key] = opt.value
+		}
+	}
+	db.batchOpts = make(map[string]*batchOpt)
 	return nil
 }
 
-// DisableBatch disable batch write.
+// NewWriteBatch returns a batch applied under the lock of the storage.
+func (db *MemoryDB) NewWriteBatch() WriteBatch {
+	return newWriteBatch(func(opts []*batchOpt) error {
+		db.mutex.Lock()
+		defer db.mutex.Unlock()
+
+		for _, opt := range opts {
+			if opt.deleted {
+				delete(db.data, string(opt.key))
+			} else {
+				db.data[string(opt.key)] = append([]byte{}, opt.value...)
+			}
+		}
+		return nil
+	})
+}
+
+// DisableBatch disable batch write, dropping the pending batch.
 func (db *MemoryDB) DisableBatch() {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	db.batchOpts = make(map[string]*batchOpt)
+	db.enableBatch = false
 }
 
 func (db *MemoryDB) Has(key []byte) (bool, error) {
-	_, ok := db.data.Load(key)
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	_, ok := db.data[string(key)]
 	return ok, nil
 }
 
 func (db *MemoryDB) ValueSize() int {
-	return 0
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+	return len(db.batchOpts)
 }
 
 func (db *MemoryDB) Close() error {
@@ -91,6 +144,102 @@ func (db *MemoryDB) Close() error {
 }
 
 func (db *MemoryDB) Delete(key []byte) error {
-	db.data.Delete(key)
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.enableBatch {
+		db.batchOpts[string(key)] = &batchOpt{
+			key:     key,
+			deleted: true,
+		}
+		return nil
+	}
+	delete(db.data, string(key))
 	return nil
 }
+
+func (db *MemoryDB) NewIterator() Iterator {
+	return db.NewIteratorWithPrefix(nil)
+}
+
+// NewIteratorWithPrefix iterate the keys with prefix in order, over a
+// snapshot of the storage taken when the iterator is created.
+func (db *MemoryDB) NewIteratorWithPrefix(prefix []byte) Iterator {
+	return db.NewIteratorWithRange(BytesPrefix(prefix))
+}
+
+// NewIteratorWithRange iterate the keys in [start, limit) in order, over a
+// snapshot of the storage taken when the iterator is created.
+func (db *MemoryDB) NewIteratorWithRange(start []byte, limit []byte) Iterator {
+	db.mutex.RLock()
+	defer db.mutex.RUnlock()
+
+	it := &memoryIterator{pos: -1}
+	for key, value := range db.data {
+		if KeyInRange([]byte(key), start, limit) {
+			it.entries = append(it.entries, &batchOpt{key: []byte(key), value: value})
+		}
+	}
+	sort.Slice(it.entries, func(i, j int) bool {
+		return bytes.Compare(it.entries[i].key, it.entries[j].key) < 0
+	})
+	return it
+}
+
+func (db *MemoryDB) DeleteRange(start []byte, limit []byte) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	for key := range db.data {
+		if !KeyInRange([]byte(key), start, limit) {
+			continue
+		}
+		if db.enableBatch {
+			db.batchOpts[key] = &batchOpt{
+				key:     []byte(key),
+				deleted: true,
+			}
+		} else {
+			delete(db.data, key)
+		}
+	}
+	if db.enableBatch {
+		markRangeDeleted(db.batchOpts, start, limit)
+	}
+	return nil
+}
+
+// memoryIterator iterate a sorted snapshot of the entries.
+type memoryIterator struct {
+	entries []*batchOpt
+	pos     int
+}
+
+func (it *memoryIterator) Next() bool {
+	if it.pos >= len(it.entries) {
+		return false
+	}
+	it.pos++
+	return it.pos < len(it.entries)
+}
+
+func (it *memoryIterator) Error() error { return nil }
+
+func (it *memoryIterator) Key() []byte {
+	if it.pos < 0 || it.pos >= len(it.entries) {
+		return nil
+	}
+	return it.entries[it.pos].key
+}
+
+func (it *memoryIterator) Value() []byte {
+	if it.pos < 0 || it.pos >= len(it.entries) {
+		return nil
+	}
+	return it.entries[it.pos].value
+}
+
+func (it *memoryIterator) Release() {
+	it.entries = nil
+	it.pos = 0
+}
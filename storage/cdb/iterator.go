@@ -17,6 +17,10 @@
 //
 package cdb
 
+import (
+	"bytes"
+)
+
 //
 type Iterator interface {
 	Next() bool
@@ -30,4 +34,34 @@ type Iterator interface {
 type Iteratee interface {
 	NewIterator() Iterator
 	NewIteratorWithPrefix(prefix []byte) Iterator
+	// NewIteratorWithRange iterate the keys in [start, limit) in order, a nil limit means no upper bound.
+	NewIteratorWithRange(start []byte, limit []byte) Iterator
+}
+
+// BytesPrefix returns the key range of the keys with prefix.
+func BytesPrefix(prefix []byte) (start []byte, limit []byte) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if c := prefix[i]; c < 0xff {
+			limit = make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			break
+		}
+	}
+	return prefix, limit
+}
+
+// KeyInRange returns true if key is in [start, limit), a nil limit means no upper bound.
+func KeyInRange(key []byte, start []byte, limit []byte) bool {
+	return bytes.Compare(key, start) >= 0 && (limit == nil || bytes.Compare(key, limit) < 0)
+}
+
+// markRangeDeleted turn the pending batch operations on the keys in [start, limit) into deletions.
+func markRangeDeleted(batchOpts map[string]*batchOpt, start []byte, limit []byte) {
+	for _, opt := range batchOpts {
+		if KeyInRange(opt.key, start, limit) {
+			opt.value = nil
+			opt.deleted = true
+		}
+	}
 }
@@ -0,0 +1,146 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package cdb
+
+import (
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+	"gamc.pro/gamcio/go-gamc/util/logging"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"sync/atomic"
+	"time"
+)
+
+// StatsInterval the interval to collect the statistics of the storage.
+const StatsInterval = 10 * time.Second
+
+var (
+	ErrCompactionNotSupported = errors.New("the storage does not support compaction")
+	ErrCompactionInProgress   = errors.New("a compaction is in progress")
+)
+
+// Maintainer exports the statistics of the storage as metrics, and compacts
+// the storage every CompactInterval seconds of the DbConfig if it is set.
+type Maintainer struct {
+	db              Storage
+	compactInterval time.Duration
+	compacting      int32
+	quitCh          chan int
+}
+
+// NewMaintainer create a maintainer of db.
+func NewMaintainer(db Storage, dbcfg *DbConfig) *Maintainer {
+	return &Maintainer{
+		db:              db,
+		compactInterval: time.Duration(dbcfg.CompactInterval) * time.Second,
+		quitCh:          make(chan int, 1),
+	}
+}
+
+// Start start loop.
+func (m *Maintainer) Start() {
+	logging.CLog().WithFields(logrus.Fields{
+		"compactInterval": m.compactInterval,
+	}).Info("Starting Storage Maintainer...")
+
+	go m.loop()
+}
+
+// Stop stop loop.
+func (m *Maintainer) Stop() {
+	logging.CLog().Info("Stopping Storage Maintainer...")
+
+	m.quitCh <- 0
+}
+
+func (m *Maintainer) loop() {
+	logging.CLog().Info("Started Storage Maintainer.")
+
+	statsTicker := time.NewTicker(StatsInterval)
+	defer statsTicker.Stop()
+
+	var compactChan <-chan time.Time
+	if m.compactInterval > 0 {
+		compactTicker := time.NewTicker(m.compactInterval)
+		defer compactTicker.Stop()
+		compactChan = compactTicker.C
+	}
+
+	for {
+		select {
+		case <-m.quitCh:
+			logging.CLog().Info("Stopped Storage Maintainer.")
+			return
+		case <-statsTicker.C:
+			m.collectStats()
+		case <-compactChan:
+			if _, err := m.Compact(nil, nil); err != nil && err != ErrCompactionInProgress {
+				logging.VLog().WithFields(logrus.Fields{
+					"err": err,
+				}).Error("Failed to compact the storage.")
+			}
+		}
+	}
+}
+
+func (m *Maintainer) collectStats() {
+	reporter, ok := m.db.(StatsReporter)
+	if !ok {
+		return
+	}
+	stats, err := reporter.Stats()
+	if err != nil {
+		logging.VLog().WithFields(logrus.Fields{
+			"err": err,
+		}).Debug("Failed to collect the storage stats.")
+		return
+	}
+	updateStatsMetrics(stats)
+}
+
+// Compact compact the keys in [start, limit) of the storage, nil start and
+// limit compact the whole storage. Only one compaction runs at a time.
+func (m *Maintainer) Compact(start []byte, limit []byte) (time.Duration, error) {
+	compacter, ok := m.db.(Compacter)
+	if !ok {
+		return 0, ErrCompactionNotSupported
+	}
+	if !atomic.CompareAndSwapInt32(&m.compacting, 0, 1) {
+		return 0, ErrCompactionInProgress
+	}
+	defer atomic.StoreInt32(&m.compacting, 0)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"start": byteutils.Hex(start),
+		"limit": byteutils.Hex(limit),
+	}).Info("Compacting the storage.")
+
+	begin := time.Now()
+	if err := compacter.Compact(start, limit); err != nil {
+		return 0, err
+	}
+	cost := time.Since(begin)
+	metricsCompactTimer.Update(cost)
+
+	logging.VLog().WithFields(logrus.Fields{
+		"start": byteutils.Hex(start),
+		"limit": byteutils.Hex(limit),
+		"cost":  cost,
+	}).Info("Compacted the storage.")
+	return cost, nil
+}
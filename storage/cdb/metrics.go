@@ -0,0 +1,57 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package cdb
+
+import (
+	"gamc.pro/gamcio/go-gamc/metrics"
+	"fmt"
+	"time"
+)
+
+var (
+	metricsIORead             = metrics.NewGauge("gamc.db.io.read")
+	metricsIOWrite            = metrics.NewGauge("gamc.db.io.write")
+	metricsReadAmplification  = metrics.NewGaugeFloat64("gamc.db.amplification.read")
+	metricsWriteAmplification = metrics.NewGaugeFloat64("gamc.db.amplification.write")
+	metricsCompactionTime     = metrics.NewGauge("gamc.db.compaction.time")
+	metricsWriteDelay         = metrics.NewGauge("gamc.db.write.delay")
+	metricsLevels             = metrics.NewGauge("gamc.db.levels")
+
+	metricsCompactTimer = metrics.NewTimer("gamc.db.compact")
+)
+
+// updateStatsMetrics update the gauges of the storage and of each level of it,
+// the times are in milliseconds.
+func updateStatsMetrics(stats *Stats) {
+	metricsIORead.Update(int64(stats.IORead))
+	metricsIOWrite.Update(int64(stats.IOWrite))
+	metricsReadAmplification.Update(stats.ReadAmplification())
+	metricsWriteAmplification.Update(stats.WriteAmplification())
+	metricsCompactionTime.Update(int64(stats.CompactionTime() / time.Millisecond))
+	metricsWriteDelay.Update(int64(stats.WriteDelay / time.Millisecond))
+	metricsLevels.Update(int64(len(stats.Levels)))
+
+	for i, level := range stats.Levels {
+		prefix := fmt.Sprintf("gamc.db.level%d.", i)
+		metrics.NewGauge(prefix + "tables").Update(int64(level.Tables))
+		metrics.NewGauge(prefix + "size").Update(level.Size)
+		metrics.NewGauge(prefix + "read").Update(level.Read)
+		metrics.NewGauge(prefix + "write").Update(level.Write)
+		metrics.NewGauge(prefix + "compaction.time").Update(int64(level.CompactionTime / time.Millisecond))
+	}
+}
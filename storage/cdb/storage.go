@@ -19,10 +19,13 @@ package cdb
 
 import (
 	"errors"
+	"time"
 )
 
 var (
-	ErrKeyNotFound = errors.New("not found")
+	ErrKeyNotFound         = errors.New("not found")
+	ErrUnsupportedProperty = errors.New("unsupported property")
+	ErrBatchTooBig         = errors.New("batch exceeds the size bound of the storage")
 )
 
 //
@@ -37,6 +40,12 @@ type Writer interface {
 	Put(key, value []byte) error
 }
 
+//
+type RangeDeleter interface {
+	// DeleteRange delete all the keys in [start, limit), a nil limit means no upper bound.
+	DeleteRange(start []byte, limit []byte) error
+}
+
 //
 type Stater interface {
 	Stat(property string) (string, error)
@@ -47,9 +56,64 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+//
+type StatsReporter interface {
+	Stats() (*Stats, error)
+}
+
+// LevelStats the statistics of a level of an LSM tree.
+type LevelStats struct {
+	Tables int
+	// Size, Read and Write in bytes.
+	Size           int64
+	Read           int64
+	Write          int64
+	CompactionTime time.Duration
+}
+
+// Stats the statistics of an LSM tree storage.
+type Stats struct {
+	Levels []LevelStats
+	// IORead and IOWrite the bytes read from and written to the disk.
+	IORead  uint64
+	IOWrite uint64
+	// UserRead and UserWrite the bytes read and written by the callers.
+	UserRead   uint64
+	UserWrite  uint64
+	WriteDelay time.Duration
+}
+
+// ReadAmplification returns the bytes read from the disk for each byte read by the callers.
+func (s *Stats) ReadAmplification() float64 {
+	if s.UserRead == 0 {
+		return 0
+	}
+	return float64(s.IORead) / float64(s.UserRead)
+}
+
+// WriteAmplification returns the bytes written to the disk for each byte written by the callers.
+func (s *Stats) WriteAmplification() float64 {
+	if s.UserWrite == 0 {
+		return 0
+	}
+	return float64(s.IOWrite) / float64(s.UserWrite)
+}
+
+// CompactionTime returns the time spent compacting all the levels.
+func (s *Stats) CompactionTime() time.Duration {
+	var total time.Duration
+	for _, level := range s.Levels {
+		total += level.CompactionTime
+	}
+	return total
+}
+
 type Storage interface {
 	Reader
 	Writer
+	RangeDeleter
+	Iteratee
 	Close() error
 	Batcher
+	WriteBatcher
 }
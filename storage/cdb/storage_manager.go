@@ -26,16 +26,40 @@ import (
 	"gamc.pro/gamcio/go-gamc/util/logging"
 	"errors"
 	"github.com/sirupsen/logrus"
+	"path/filepath"
 )
 
 const (
-	TypeLevelDB = "levelDB"
+	TypeLevelDB  = "levelDB"
+	TypeBoltDB   = "bolt"
+	TypeBadgerDB = "badger"
+
+	// DefaultCache the default size of the block cache in MiB
+	DefaultCache = 16
+	// DefaultHandles the default number of open files
+	DefaultHandles = 500
+	// DefaultBlockCache the default size of the block cache of the chain in bytes
+	DefaultBlockCache = 16 << 20
+	// DefaultTrieCache the default size of the trie node cache of the chain in bytes
+	DefaultTrieCache = 64 << 20
 )
 
 type DbConfig struct {
 	DbType      string `yaml:"db_type"`
 	EnableBatch bool   `yaml:"enable_batch"`
 	DbDir       string `yaml:"db_dir"`
+	// Cache the size of the block cache in MiB
+	Cache int `yaml:"cache"`
+	// Handles the number of open files
+	Handles int `yaml:"handles"`
+	// WriteBuffer the size of the memtable in MiB, defaults to a quarter of the cache
+	WriteBuffer int `yaml:"write_buffer"`
+	// CompactInterval the seconds between two full compactions, 0 disables the scheduled compactions
+	CompactInterval int `yaml:"compact_interval"`
+	// BlockCache the size in bytes of the cache of the stored blocks, negative to disable it
+	BlockCache int `yaml:"block_cache"`
+	// TrieCache the size in bytes of the cache of the trie nodes, negative to disable it
+	TrieCache int `yaml:"trie_cache"`
 }
 
 func GetDbConfig(config *config.Config) *DbConfig {
@@ -45,12 +69,24 @@ func GetDbConfig(config *config.Config) *DbConfig {
 		if dbConf.DbType == "" {
 			dbConf.DbType = TypeLevelDB
 		}
+		if dbConf.Cache == 0 {
+			dbConf.Cache = DefaultCache
+		}
+		if dbConf.Handles == 0 {
+			dbConf.Handles = DefaultHandles
+		}
+		if dbConf.BlockCache == 0 {
+			dbConf.BlockCache = DefaultBlockCache
+		}
+		if dbConf.TrieCache == 0 {
+			dbConf.TrieCache = DefaultTrieCache
+		}
 	} else {
 		dbConf = NewDefaultDbConfig()
 	}
 	if dbConf.DbDir == "" {
 		chaincfg := conf.GetChainConfig(config)
-		dbPath := chaincfg.Datadir + "\\chain"
+		dbPath := filepath.Join(chaincfg.Datadir, "chain")
 		dbConf.DbDir = dbPath
 	}
 	return dbConf
@@ -61,25 +97,44 @@ func NewDefaultDbConfig() *DbConfig {
 		TypeLevelDB,
 		false,
 		"",
+		DefaultCache,
+		DefaultHandles,
+		0,
+		0,
+		DefaultBlockCache,
+		DefaultTrieCache,
 	}
 }
 
 func NewDB(config *config.Config) (Storage, error) {
-	dbcfg := GetDbConfig(config)
-	if dbcfg.DbType == TypeLevelDB {
-		db, err := NewLevelDB(dbcfg, 16, 500)
-		if err != nil {
-			logging.CLog().WithFields(logrus.Fields{
-				"dir": dbcfg.DbDir,
-				"err": err,
-			}).Error("Failed to new a levelDB instance.")
-			return nil, err
-		}
-		return db, nil
-	} else {
+	return NewDBWithConfig(GetDbConfig(config))
+}
+
+// NewDBWithConfig open the database described by dbcfg.
+func NewDBWithConfig(dbcfg *DbConfig) (Storage, error) {
+	var (
+		db  Storage
+		err error
+	)
+	switch dbcfg.DbType {
+	case TypeLevelDB:
+		db, err = NewLevelDB(dbcfg, dbcfg.Cache, dbcfg.Handles)
+	case TypeBoltDB:
+		db, err = NewBoltDB(dbcfg)
+	case TypeBadgerDB:
+		db, err = NewBadgerDB(dbcfg)
+	default:
 		return nil, errors.New(fmt.Sprintf("Does not support the %s database.", dbcfg.DbType))
 	}
-
+	if err != nil {
+		logging.CLog().WithFields(logrus.Fields{
+			"type": dbcfg.DbType,
+			"dir":  dbcfg.DbDir,
+			"err":  err,
+		}).Error("Failed to new a database instance.")
+		return nil, err
+	}
+	return db, nil
 }
 
 type Database struct {
@@ -98,6 +153,21 @@ func (d *Database) Put(key, value []byte) error {
 func (d *Database) Delete(key []byte) error {
 	return d.Db.Delete(key)
 }
+func (d *Database) DeleteRange(start []byte, limit []byte) error {
+	return d.Db.DeleteRange(start, limit)
+}
+func (d *Database) NewIterator() Iterator {
+	return d.Db.NewIterator()
+}
+func (d *Database) NewIteratorWithPrefix(prefix []byte) Iterator {
+	return d.Db.NewIteratorWithPrefix(prefix)
+}
+func (d *Database) NewIteratorWithRange(start []byte, limit []byte) Iterator {
+	return d.Db.NewIteratorWithRange(start, limit)
+}
+func (d *Database) NewWriteBatch() WriteBatch {
+	return d.Db.NewWriteBatch()
+}
 func (d *Database) Close() error {
 	return d.Db.Close()
 }
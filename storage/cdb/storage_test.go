@@ -0,0 +1,270 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package cdb_test
+
+import (
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/storage/cdb/storagetest"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMemoryDB(t *testing.T) {
+	storagetest.TestStorage(t, func() (cdb.Storage, func(), error) {
+		db, err := cdb.NewMemoryStorage()
+		return db, func() {}, err
+	})
+}
+
+func TestTable(t *testing.T) {
+	storagetest.TestStorage(t, func() (cdb.Storage, func(), error) {
+		db, err := cdb.NewMemoryStorage()
+		if err != nil {
+			return nil, nil, err
+		}
+		// surround the table with the keys of other tables.
+		db.Put([]byte("s"), []byte("other"))
+		db.Put([]byte("sa"), []byte("other"))
+		db.Put([]byte("u"), []byte("other"))
+		return cdb.NewTable(db, "t"), func() {}, nil
+	})
+}
+
+func TestTableIsolation(t *testing.T) {
+	db, _ := cdb.NewMemoryStorage()
+	a, b := cdb.NewTable(db, "a"), cdb.NewTable(db, "b")
+
+	a.Put([]byte("key"), []byte("a"))
+	b.Put([]byte("key"), []byte("b"))
+	if value, err := a.Get([]byte("key")); err != nil || string(value) != "a" {
+		t.Fatalf("get from table a: %q %v", value, err)
+	}
+	if err := b.DeleteRange(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Get([]byte("key")); err != cdb.ErrKeyNotFound {
+		t.Fatalf("get from cleared table b: %v", err)
+	}
+	if value, err := db.Get([]byte("akey")); err != nil || string(value) != "a" {
+		t.Fatalf("get from storage: %q %v", value, err)
+	}
+}
+
+func TestCachedStorage(t *testing.T) {
+	storagetest.TestStorage(t, func() (cdb.Storage, func(), error) {
+		db, err := cdb.NewMemoryStorage()
+		if err != nil {
+			return nil, nil, err
+		}
+		// small enough to evict during the tests.
+		return cdb.NewCachedStorage(db, "test", 16), func() {}, nil
+	})
+}
+
+func TestCachedStorageBatch(t *testing.T) {
+	db, _ := cdb.NewMemoryStorage()
+	cache := cdb.NewCachedStorage(db, "test", 1024)
+	key := []byte("key")
+
+	db.Put(key, []byte("v1"))
+	mustGetValue(t, cache, key, "v1")
+
+	// the storage is flushed and discarded without going through the cache.
+	db.EnableBatch()
+	cache.Put(key, []byte("v2"))
+	mustGetValue(t, cache, key, "v1")
+	db.Flush()
+	mustGetValue(t, cache, key, "v2")
+
+	cache.Delete(key)
+	mustGetValue(t, cache, key, "v2")
+	db.DisableBatch()
+	mustGetValue(t, cache, key, "v2")
+
+	db.EnableBatch()
+	cache.DeleteRange(nil, nil)
+	mustGetValue(t, cache, key, "v2")
+	cache.Flush()
+	if _, err := cache.Get(key); err != cdb.ErrKeyNotFound {
+		t.Fatalf("get deleted key: %v", err)
+	}
+}
+
+func mustGetValue(t *testing.T, db cdb.Storage, key []byte, want string) {
+	t.Helper()
+	value, err := db.Get(key)
+	if err != nil || string(value) != want {
+		t.Fatalf("get %q: %q %v, want %q", key, value, err, want)
+	}
+}
+
+func TestLevelDB(t *testing.T) {
+	storagetest.TestStorage(t, func() (cdb.Storage, func(), error) {
+		dir, err := ioutil.TempDir("", "leveldb")
+		if err != nil {
+			return nil, nil, err
+		}
+		db, err := cdb.NewLevelDB(&cdb.DbConfig{DbType: cdb.TypeLevelDB, DbDir: dir}, 16, 16)
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, nil, err
+		}
+		return db, func() {
+			db.Close()
+			os.RemoveAll(dir)
+		}, nil
+	})
+}
+
+func TestLevelDBStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "leveldb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := cdb.NewLevelDB(&cdb.DbConfig{DbType: cdb.TypeLevelDB, DbDir: dir}, 16, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for i := 0; i < 1000; i++ {
+		key := []byte(fmt.Sprintf("key%04d", i))
+		if err := db.Put(key, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	maintainer := cdb.NewMaintainer(db, &cdb.DbConfig{})
+	if _, err := maintainer.Compact(nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := db.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables := 0
+	for _, level := range stats.Levels {
+		tables += level.Tables
+	}
+	if tables == 0 || stats.UserWrite == 0 || stats.WriteAmplification() <= 0 {
+		t.Fatalf("unexpected stats after compaction: %+v", stats)
+	}
+	if _, err := db.Stat("leveldb.stats"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Stat("leveldb.unknown"); err != cdb.ErrUnsupportedProperty {
+		t.Fatalf("stat unknown property: %v", err)
+	}
+}
+
+func TestBoltDB(t *testing.T) {
+	storagetest.TestStorage(t, func() (cdb.Storage, func(), error) {
+		dir, err := ioutil.TempDir("", "boltdb")
+		if err != nil {
+			return nil, nil, err
+		}
+		db, err := cdb.NewBoltDB(&cdb.DbConfig{DbType: cdb.TypeBoltDB, DbDir: filepath.Join(dir, "chain.db")})
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, nil, err
+		}
+		return db, func() {
+			db.Close()
+			os.RemoveAll(dir)
+		}, nil
+	})
+}
+
+func TestBadgerDB(t *testing.T) {
+	storagetest.TestStorage(t, func() (cdb.Storage, func(), error) {
+		dir, err := ioutil.TempDir("", "badgerdb")
+		if err != nil {
+			return nil, nil, err
+		}
+		db, err := cdb.NewBadgerDB(&cdb.DbConfig{DbType: cdb.TypeBadgerDB, DbDir: dir})
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, nil, err
+		}
+		return db, func() {
+			db.Close()
+			os.RemoveAll(dir)
+		}, nil
+	})
+}
+
+func TestBadgerDBBatchBound(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badgerdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := cdb.NewBadgerDB(&cdb.DbConfig{DbType: cdb.TypeBadgerDB, DbDir: dir, WriteBuffer: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// a batch of the ideal size is accepted.
+	batch := db.NewWriteBatch()
+	for i := 0; i < cdb.IdealBatchLen; i++ {
+		if err := batch.Put([]byte(fmt.Sprintf("ideal%d", i)), make([]byte, cdb.IdealBatchSize/cdb.IdealBatchLen)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatalf("write ideal batch: %v", err)
+	}
+
+	// a batch over the bound is refused as a whole.
+	batch = db.NewWriteBatch()
+	for i := 0; i < 10*cdb.IdealBatchLen; i++ {
+		if err := batch.Put([]byte(fmt.Sprintf("key%d", i)), []byte("value")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Write(); err != cdb.ErrBatchTooBig {
+		t.Fatalf("write big batch: %v, want %v", err, cdb.ErrBatchTooBig)
+	}
+	if has, _ := db.Has([]byte("key0")); has {
+		t.Fatal("key of a refused batch was written")
+	}
+
+	// so is a flush over the bound, the writes stay pending.
+	db.EnableBatch()
+	defer db.DisableBatch()
+	for i := 0; i < 10*cdb.IdealBatchLen; i++ {
+		if err := db.Put([]byte(fmt.Sprintf("key%d", i)), []byte("value")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.Flush(); err != cdb.ErrBatchTooBig {
+		t.Fatalf("flush big batch: %v, want %v", err, cdb.ErrBatchTooBig)
+	}
+	if has, _ := db.Has([]byte("key0")); has {
+		t.Fatal("key of a refused flush was written")
+	}
+	if size := db.ValueSize(); size != 10*cdb.IdealBatchLen {
+		t.Fatalf("pending writes after refused flush: %d, want %d", size, 10*cdb.IdealBatchLen)
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+// Package storagetest is the conformance suite every cdb.Storage backend
+// must pass.
+package storagetest
+
+import (
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"bytes"
+	"testing"
+)
+
+// NewStorage returns an empty storage, and a func to close and remove it.
+type NewStorage func() (cdb.Storage, func(), error)
+
+// TestStorage run the conformance suite against the storages created by newStorage.
+func TestStorage(t *testing.T, newStorage NewStorage) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, db cdb.Storage)
+	}{
+		{"GetPutDelete", testGetPutDelete},
+		{"KeyNotFound", testKeyNotFound},
+		{"BatchAtomicity", testBatchAtomicity},
+		{"BatchDiscard", testBatchDiscard},
+		{"WriteBatch", testWriteBatch},
+		{"IterationOrder", testIterationOrder},
+		{"IterationRange", testIterationRange},
+		{"DeleteRange", testDeleteRange},
+		{"BatchDeleteRange", testBatchDeleteRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, closeFn, err := newStorage()
+			if err != nil {
+				t.Fatalf("failed to create storage: %v", err)
+			}
+			defer closeFn()
+			tt.fn(t, db)
+		})
+	}
+}
+
+func testGetPutDelete(t *testing.T, db cdb.Storage) {
+	key, value := []byte("key"), []byte("value")
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	mustGet(t, db, key, value)
+	if ok, err := db.Has(key); err != nil || !ok {
+		t.Fatalf("has: %v %v, want true", ok, err)
+	}
+
+	// overwrite.
+	value = []byte("other")
+	if err := db.Put(key, value); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	mustGet(t, db, key, value)
+
+	// empty value is kept.
+	if err := db.Put([]byte("empty"), []byte{}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if ok, err := db.Has([]byte("empty")); err != nil || !ok {
+		t.Fatalf("has empty value: %v %v, want true", ok, err)
+	}
+
+	if err := db.Delete(key); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	mustMiss(t, db, key)
+	if err := db.Delete([]byte("missing")); err != nil {
+		t.Fatalf("delete missing key: %v", err)
+	}
+}
+
+func testKeyNotFound(t *testing.T, db cdb.Storage) {
+	if value, err := db.Get([]byte("missing")); err != cdb.ErrKeyNotFound {
+		t.Fatalf("get missing key: %x %v, want %v", value, err, cdb.ErrKeyNotFound)
+	}
+	if ok, err := db.Has([]byte("missing")); err != nil || ok {
+		t.Fatalf("has missing key: %v %v, want false", ok, err)
+	}
+}
+
+func testBatchAtomicity(t *testing.T, db cdb.Storage) {
+	if err := db.Put([]byte("deleted"), []byte("value")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	db.EnableBatch()
+	for _, k := range []string{"a", "b", "c"} {
+		if err := db.Put([]byte(k), []byte("v"+k)); err != nil {
+			t.Fatalf("batch put: %v", err)
+		}
+	}
+	if err := db.Delete([]byte("deleted")); err != nil {
+		t.Fatalf("batch delete: %v", err)
+	}
+	// the last write of a key in a batch wins.
+	if err := db.Put([]byte("b"), []byte("last")); err != nil {
+		t.Fatalf("batch put: %v", err)
+	}
+
+	// nothing is visible before the flush.
+	mustMiss(t, db, []byte("a"))
+	mustGet(t, db, []byte("deleted"), []byte("value"))
+
+	if err := db.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	db.DisableBatch()
+
+	mustGet(t, db, []byte("a"), []byte("va"))
+	mustGet(t, db, []byte("b"), []byte("last"))
+	mustGet(t, db, []byte("c"), []byte("vc"))
+	mustMiss(t, db, []byte("deleted"))
+}
+
+func testBatchDiscard(t *testing.T, db cdb.Storage) {
+	db.EnableBatch()
+	if err := db.Put([]byte("a"), []byte("va")); err != nil {
+		t.Fatalf("batch put: %v", err)
+	}
+	db.DisableBatch()
+	if err := db.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	mustMiss(t, db, []byte("a"))
+
+	// writes are direct again once batch is disabled.
+	if err := db.Put([]byte("b"), []byte("vb")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	mustGet(t, db, []byte("b"), []byte("vb"))
+}
+
+func testWriteBatch(t *testing.T, db cdb.Storage) {
+	if err := db.Put([]byte("deleted"), []byte("value")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	batch := db.NewWriteBatch()
+	key := []byte("a")
+	if err := batch.Put(key, []byte("va")); err != nil {
+		t.Fatalf("batch put: %v", err)
+	}
+	// the batch keeps its own copy of the key.
+	key[0] = 'b'
+	if err := batch.Put(key, []byte("vb")); err != nil {
+		t.Fatalf("batch put: %v", err)
+	}
+	if err := batch.Delete([]byte("deleted")); err != nil {
+		t.Fatalf("batch delete: %v", err)
+	}
+	if batch.Len() != 3 {
+		t.Fatalf("batch len: %d, want 3", batch.Len())
+	}
+
+	// nothing is visible before the write, the global batch doesn't flush it.
+	db.EnableBatch()
+	if err := db.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	db.DisableBatch()
+	mustMiss(t, db, []byte("a"))
+	mustGet(t, db, []byte("deleted"), []byte("value"))
+
+	if err := batch.Write(); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	mustGet(t, db, []byte("a"), []byte("va"))
+	mustGet(t, db, []byte("b"), []byte("vb"))
+	mustMiss(t, db, []byte("deleted"))
+
+	// a reset batch writes nothing.
+	batch.Reset()
+	if err := db.Put([]byte("c"), []byte("vc")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if err := batch.Delete([]byte("c")); err != nil {
+		t.Fatalf("batch delete: %v", err)
+	}
+	batch.Reset()
+	if err := batch.Write(); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	mustGet(t, db, []byte("c"), []byte("vc"))
+}
+
+// putKeys put the keys used by the iteration tests, the value of a key is "v" + key.
+func putKeys(t *testing.T, db cdb.Storage) {
+	keys := [][]byte{
+		[]byte("b\xff"), []byte("a"), []byte("b"), []byte("b\x00"),
+		[]byte("ba"), []byte("c"), []byte("b\x01\x02"),
+	}
+	for _, k := range keys {
+		if err := db.Put(k, append([]byte("v"), k...)); err != nil {
+			t.Fatalf("put: %v", err)
+		}
+	}
+}
+
+func testIterationOrder(t *testing.T, db cdb.Storage) {
+	putKeys(t, db)
+
+	mustIterate(t, db.NewIteratorWithPrefix([]byte("b")),
+		"b", "b\x00", "b\x01\x02", "ba", "b\xff")
+	mustIterate(t, db.NewIterator(),
+		"a", "b", "b\x00", "b\x01\x02", "ba", "b\xff", "c")
+	mustIterate(t, db.NewIteratorWithPrefix([]byte("d")))
+}
+
+func testIterationRange(t *testing.T, db cdb.Storage) {
+	putKeys(t, db)
+
+	mustIterate(t, db.NewIteratorWithRange([]byte("b\x00"), []byte("b\xff")),
+		"b\x00", "b\x01\x02", "ba")
+	mustIterate(t, db.NewIteratorWithRange([]byte("b1"), nil),
+		"ba", "b\xff", "c")
+	mustIterate(t, db.NewIteratorWithRange(nil, []byte("b")),
+		"a")
+	mustIterate(t, db.NewIteratorWithRange([]byte("c"), []byte("c")))
+}
+
+func testDeleteRange(t *testing.T, db cdb.Storage) {
+	putKeys(t, db)
+
+	if err := db.DeleteRange([]byte("b\x00"), []byte("b\xff")); err != nil {
+		t.Fatalf("delete range: %v", err)
+	}
+	mustIterate(t, db.NewIterator(), "a", "b", "b\xff", "c")
+	mustMiss(t, db, []byte("ba"))
+
+	if err := db.DeleteRange([]byte("b"), nil); err != nil {
+		t.Fatalf("delete range: %v", err)
+	}
+	mustIterate(t, db.NewIterator(), "a")
+}
+
+func testBatchDeleteRange(t *testing.T, db cdb.Storage) {
+	putKeys(t, db)
+
+	db.EnableBatch()
+	if err := db.Put([]byte("bb"), []byte("vbb")); err != nil {
+		t.Fatalf("batch put: %v", err)
+	}
+	if err := db.DeleteRange([]byte("b"), []byte("c")); err != nil {
+		t.Fatalf("batch delete range: %v", err)
+	}
+	// the keys written after the deletion are kept.
+	if err := db.Put([]byte("b"), []byte("vb")); err != nil {
+		t.Fatalf("batch put: %v", err)
+	}
+
+	// nothing is deleted before the flush.
+	mustGet(t, db, []byte("ba"), []byte("vba"))
+
+	if err := db.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	db.DisableBatch()
+
+	mustIterate(t, db.NewIterator(), "a", "b", "c")
+}
+
+func mustGet(t *testing.T, db cdb.Storage, key, want []byte) {
+	t.Helper()
+	value, err := db.Get(key)
+	if err != nil {
+		t.Fatalf("get %q: %v", key, err)
+	}
+	if !bytes.Equal(value, want) {
+		t.Fatalf("get %q: %q, want %q", key, value, want)
+	}
+}
+
+func mustMiss(t *testing.T, db cdb.Storage, key []byte) {
+	t.Helper()
+	if value, err := db.Get(key); err != cdb.ErrKeyNotFound {
+		t.Fatalf("get %q: %q %v, want %v", key, value, err, cdb.ErrKeyNotFound)
+	}
+	if ok, err := db.Has(key); err != nil || ok {
+		t.Fatalf("has %q: %v %v, want false", key, ok, err)
+	}
+}
+
+func mustIterate(t *testing.T, it cdb.Iterator, want ...string) {
+	t.Helper()
+	defer it.Release()
+
+	var got []string
+	for it.Next() {
+		if value := it.Value(); !bytes.Equal(value, append([]byte("v"), it.Key()...)) {
+			t.Fatalf("iterate %q: value %q", it.Key(), value)
+		}
+		got = append(got, string(it.Key()))
+	}
+	if err := it.Error(); err != nil {
+		t.Fatalf("iterate: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("iterate: %q, want %q", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("iterate: %q, want %q", got, want)
+		}
+	}
+}
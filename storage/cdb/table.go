@@ -0,0 +1,155 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package cdb
+
+// Table a Storage prefixing all its keys, so that several key spaces can share
+// a single storage without collisions. Batch operations apply to the whole
+// shared storage.
+type Table struct {
+	db     Storage
+	prefix []byte
+}
+
+// NewTable returns the table of db with prefix, which must not be the prefix
+// of another table of db.
+func NewTable(db Storage, prefix string) *Table {
+	return &Table{
+		db:     db,
+		prefix: []byte(prefix),
+	}
+}
+
+// Prefix returns the prefix of the keys of the table in the shared storage.
+func (t *Table) Prefix() []byte {
+	return t.prefix
+}
+
+func (t *Table) key(key []byte) []byte {
+	k := make([]byte, 0, len(t.prefix)+len(key))
+	return append(append(k, t.prefix...), key...)
+}
+
+// limit returns the limit of a key range in the shared storage, a nil limit
+// is the end of the table.
+func (t *Table) limit(limit []byte) []byte {
+	if limit == nil {
+		_, limit = BytesPrefix(t.prefix)
+		return limit
+	}
+	return t.key(limit)
+}
+
+func (t *Table) Has(key []byte) (bool, error) {
+	return t.db.Has(t.key(key))
+}
+
+func (t *Table) Get(key []byte) ([]byte, error) {
+	return t.db.Get(t.key(key))
+}
+
+func (t *Table) Put(key, value []byte) error {
+	return t.db.Put(t.key(key), value)
+}
+
+func (t *Table) Delete(key []byte) error {
+	return t.db.Delete(t.key(key))
+}
+
+func (t *Table) DeleteRange(start []byte, limit []byte) error {
+	return t.db.DeleteRange(t.key(start), t.limit(limit))
+}
+
+func (t *Table) NewIterator() Iterator {
+	return t.NewIteratorWithRange(nil, nil)
+}
+
+func (t *Table) NewIteratorWithPrefix(prefix []byte) Iterator {
+	return &tableIterator{t.db.NewIteratorWithPrefix(t.key(prefix)), len(t.prefix)}
+}
+
+func (t *Table) NewIteratorWithRange(start []byte, limit []byte) Iterator {
+	return &tableIterator{t.db.NewIteratorWithRange(t.key(start), t.limit(limit)), len(t.prefix)}
+}
+
+// Compact compact the keys of the table in [start, limit), if the storage supports it.
+func (t *Table) Compact(start []byte, limit []byte) error {
+	if c, ok := t.db.(Compacter); ok {
+		return c.Compact(t.key(start), t.limit(limit))
+	}
+	return nil
+}
+
+// NewWriteBatch returns a batch of the shared storage writing into the table.
+func (t *Table) NewWriteBatch() WriteBatch {
+	return t.Batch(t.db.NewWriteBatch())
+}
+
+// Batch returns a view of batch, a write batch of the shared storage, writing
+// into the table, so that the writes of several tables are applied atomically.
+func (t *Table) Batch(batch WriteBatch) WriteBatch {
+	return &tableBatch{batch, t}
+}
+
+// Close does nothing, the shared storage is closed by its owner.
+func (t *Table) Close() error {
+	return nil
+}
+
+func (t *Table) ValueSize() int {
+	return t.db.ValueSize()
+}
+
+func (t *Table) EnableBatch() {
+	t.db.EnableBatch()
+}
+
+func (t *Table) DisableBatch() {
+	t.db.DisableBatch()
+}
+
+func (t *Table) Flush() error {
+	return t.db.Flush()
+}
+
+// tableIterator strip the prefix of the table from the keys.
+type tableIterator struct {
+	Iterator
+	prefixLen int
+}
+
+func (it *tableIterator) Key() []byte {
+	key := it.Iterator.Key()
+	if key == nil {
+		return nil
+	}
+	return key[it.prefixLen:]
+}
+
+// tableBatch prefix the keys written in a batch of the shared storage.
+type tableBatch struct {
+	WriteBatch
+	table *Table
+}
+
+func (b *tableBatch) Put(key, value []byte) error {
+	return b.WriteBatch.Put(b.table.key(key), value)
+}
+
+func (b *tableBatch) Delete(key []byte) error {
+	return b.WriteBatch.Delete(b.table.key(key))
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package mvccdb
+
+import (
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"bytes"
+	"sort"
+)
+
+// mergedIterator iterate the keys of a storage iterator overridden by the staged values.
+type mergedIterator struct {
+	base       cdb.Iterator
+	baseValid  bool
+	started    bool
+	staged     []*VersionizedValueItem
+	pos        int
+	key, value []byte
+	err        error
+}
+
+func newMergedIterator(base cdb.Iterator, values stagingValuesMap) *mergedIterator {
+	staged := make([]*VersionizedValueItem, 0, len(values))
+	for _, value := range values {
+		staged = append(staged, value)
+	}
+	sort.Slice(staged, func(i, j int) bool {
+		return bytes.Compare(staged[i].key, staged[j].key) < 0
+	})
+	return &mergedIterator{base: base, staged: staged}
+}
+
+func (it *mergedIterator) Next() bool {
+	if it.base == nil {
+		return false
+	}
+	if !it.started {
+		it.baseValid = it.base.Next()
+		it.started = true
+	}
+
+	for {
+		var stagedKey []byte
+		if it.pos < len(it.staged) {
+			stagedKey = it.staged[it.pos].key
+		}
+
+		switch {
+		case it.baseValid && (stagedKey == nil || bytes.Compare(it.base.Key(), stagedKey) < 0):
+			// the base iterator reuses its buffers.
+			it.key = append([]byte{}, it.base.Key()...)
+			it.value = append([]byte{}, it.base.Value()...)
+			it.baseValid = it.base.Next()
+			return true
+		case stagedKey != nil:
+			value := it.staged[it.pos]
+			it.pos++
+			if it.baseValid && bytes.Equal(it.base.Key(), stagedKey) {
+				it.baseValid = it.base.Next()
+			}
+			if value.deleted || value.val == nil {
+				continue
+			}
+			it.key, it.value = value.key, value.val
+			return true
+		default:
+			it.key, it.value = nil, nil
+			return false
+		}
+	}
+}
+
+func (it *mergedIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	if it.base != nil {
+		return it.base.Error()
+	}
+	return nil
+}
+
+func (it *mergedIterator) Key() []byte   { return it.key }
+func (it *mergedIterator) Value() []byte { return it.value }
+
+func (it *mergedIterator) Release() {
+	if it.base != nil {
+		it.base.Release()
+		it.base = nil
+	}
+	it.staged = nil
+	it.key, it.value = nil, nil
+}
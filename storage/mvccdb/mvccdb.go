@@ -106,8 +106,18 @@ func (db *MVCCDB) Begin() error {
 	return nil
 }
 
-// Commit commit changes to storage.
+// Commit commit changes to storage, in a single write batch of the storage.
 func (db *MVCCDB) Commit() error {
+	return db.commit(db.storage.NewWriteBatch(), true)
+}
+
+// CommitTo commit changes into batch, a write batch of the storage, which the
+// caller writes, with other changes to apply atomically.
+func (db *MVCCDB) CommitTo(batch cdb.WriteBatch) error {
+	return db.commit(batch, false)
+}
+
+func (db *MVCCDB) commit(batch cdb.WriteBatch, write bool) error {
 	db.mutex.Lock()
 	defer db.mutex.Unlock()
 
@@ -122,9 +132,6 @@ func (db *MVCCDB) Commit() error {
 	// commit.
 	db.stagingTable.Lock()
 
-	// enable batch.
-	db.storage.EnableBatch()
-
 	var err error
 	for _, value := range db.stagingTable.getVersionizedValues() {
 		// skip default value loaded from storage.
@@ -140,29 +147,25 @@ func (db *MVCCDB) Commit() error {
 			// The delete opt of Trie is not `delete`, just flag to delete.
 			// So no delete when isTrieSameKeyCompatibility == true.
 			if db.isTrieSameKeyCompatibility == false {
-				err = db.delFromStorage(value.key)
+				err = batch.Delete(value.key)
 			}
 		} else {
-			err = db.putToStorage(value.key, value.val)
+			err = batch.Put(value.key, value.val)
 		}
 
 		if err != nil {
-			db.storage.DisableBatch()
 			db.stagingTable.Unlock()
 			return err
 		}
 	}
 
-	// flush and disable batch.
-	err = db.storage.Flush()
-	if err != nil {
-		db.storage.DisableBatch()
-		db.stagingTable.Unlock()
-		return err
+	if write {
+		if err := batch.Write(); err != nil {
+			db.stagingTable.Unlock()
+			return err
+		}
 	}
 
-	db.storage.DisableBatch()
-
 	// unlock.
 	db.stagingTable.Unlock()
 
@@ -205,6 +208,53 @@ func (db *MVCCDB) RollBack() error {
 	return nil
 }
 
+// Savepoint mark the changes of the transaction, the ones made after it can be
+// discarded by RollBackToSavepoint while the others are kept. Savepoints nest,
+// the ids grow from 0, and are released by commit, rollback and reset.
+func (db *MVCCDB) Savepoint() (int, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.checkSavepointAllowed(); err != nil {
+		return 0, err
+	}
+	return db.stagingTable.Savepoint(), nil
+}
+
+// RollBackToSavepoint discard the changes made after the savepoint, and release
+// it with the savepoints nested in it.
+func (db *MVCCDB) RollBackToSavepoint(savepoint int) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.checkSavepointAllowed(); err != nil {
+		return err
+	}
+	return db.stagingTable.RollBackTo(savepoint)
+}
+
+// ReleaseSavepoint release the savepoint with the savepoints nested in it,
+// keeping the changes made after it in the transaction.
+func (db *MVCCDB) ReleaseSavepoint(savepoint int) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if err := db.checkSavepointAllowed(); err != nil {
+		return err
+	}
+	return db.stagingTable.Release(savepoint)
+}
+
+func (db *MVCCDB) checkSavepointAllowed() error {
+	if db.isPreparedDB && db.isPreparedDBClosed {
+		return ErrPreparedDBIsClosed
+	}
+	if !db.isInTransaction {
+		return ErrTransactionNotStarted
+	}
+	return nil
+}
+
 // Get value
 func (db *MVCCDB) Get(key []byte) ([]byte, error) {
 	// s := time.Now().UnixNano()
@@ -268,6 +318,68 @@ func (db *MVCCDB) Delete(key []byte) error {
 	return err
 }
 
+// DeleteRange delete all the keys in [start, limit), a nil limit means no upper bound.
+func (db *MVCCDB) DeleteRange(start []byte, limit []byte) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.isPreparedDB && db.isPreparedDBClosed {
+		return ErrPreparedDBIsClosed
+	}
+
+	if !db.isInTransaction {
+		return db.storage.DeleteRange(start, limit)
+	}
+
+	// stage a deletion for every key visible in the transaction.
+	it := db.newIterator(start, limit)
+	var keys [][]byte
+	for it.Next() {
+		keys = append(keys, it.Key())
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if _, err := db.stagingTable.Del(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewIterator iterate all the keys in order.
+func (db *MVCCDB) NewIterator() cdb.Iterator {
+	return db.NewIteratorWithRange(nil, nil)
+}
+
+// NewIteratorWithPrefix iterate the keys with prefix in order.
+func (db *MVCCDB) NewIteratorWithPrefix(prefix []byte) cdb.Iterator {
+	return db.NewIteratorWithRange(cdb.BytesPrefix(prefix))
+}
+
+// NewIteratorWithRange iterate the keys in [start, limit) in order. In a transaction,
+// the changes staged when the iterator is created are merged with the storage.
+func (db *MVCCDB) NewIteratorWithRange(start []byte, limit []byte) cdb.Iterator {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.isPreparedDB && db.isPreparedDBClosed {
+		return &mergedIterator{err: ErrPreparedDBIsClosed}
+	}
+	return db.newIterator(start, limit)
+}
+
+func (db *MVCCDB) newIterator(start []byte, limit []byte) cdb.Iterator {
+	if !db.isInTransaction {
+		return db.storage.NewIteratorWithRange(start, limit)
+	}
+	return newMergedIterator(db.storage.NewIteratorWithRange(start, limit), db.stagingTable.stagedValues(start, limit))
+}
+
 // Prepare a nested transaction
 func (db *MVCCDB) Prepare(tid interface{}) (*MVCCDB, error) {
 	db.mutex.Lock()
@@ -455,6 +567,12 @@ func (db *MVCCDB) Flush() error {
 func (db *MVCCDB) DisableBatch() {
 }
 
+// NewWriteBatch returns a batch staged in the transaction if any, or written
+// in a single write batch of the storage otherwise.
+func (db *MVCCDB) NewWriteBatch() cdb.WriteBatch {
+	return &writeBatch{db: db}
+}
+
 func (db *MVCCDB) Has(key []byte) (bool, error) {
 	value, err := db.Get(key)
 	if err != nil {
@@ -470,3 +588,68 @@ func (db *MVCCDB) Has(key []byte) (bool, error) {
 func (db *MVCCDB) ValueSize() int {
 	return 0
 }
+
+type writeOpt struct {
+	key     []byte
+	val     []byte
+	deleted bool
+}
+
+// writeBatch a WriteBatch of MVCCDB.
+type writeBatch struct {
+	db   *MVCCDB
+	opts []*writeOpt
+}
+
+func (b *writeBatch) Put(key []byte, val []byte) error {
+	b.opts = append(b.opts, &writeOpt{key: key, val: val})
+	return nil
+}
+
+func (b *writeBatch) Delete(key []byte) error {
+	b.opts = append(b.opts, &writeOpt{key: key, deleted: true})
+	return nil
+}
+
+func (b *writeBatch) Len() int {
+	return len(b.opts)
+}
+
+func (b *writeBatch) Write() error {
+	db := b.db
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	if db.isPreparedDB && db.isPreparedDBClosed {
+		return ErrPreparedDBIsClosed
+	}
+
+	if !db.isInTransaction {
+		batch := db.storage.NewWriteBatch()
+		for _, opt := range b.opts {
+			if opt.deleted {
+				batch.Delete(opt.key)
+			} else {
+				batch.Put(opt.key, opt.val)
+			}
+		}
+		return batch.Write()
+	}
+
+	for _, opt := range b.opts {
+		var err error
+		if opt.deleted {
+			_, err = db.stagingTable.Del(opt.key)
+		} else {
+			_, err = db.stagingTable.Put(opt.key, opt.val)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *writeBatch) Reset() {
+	b.opts = nil
+}
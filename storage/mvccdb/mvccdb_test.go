@@ -0,0 +1,219 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package mvccdb
+
+import (
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"testing"
+)
+
+func iterateKeys(t *testing.T, it cdb.Iterator) []string {
+	defer it.Release()
+	var keys []string
+	for it.Next() {
+		if string(it.Value()) != "v"+string(it.Key()) {
+			t.Fatalf("iterate %q: value %q", it.Key(), it.Value())
+		}
+		keys = append(keys, string(it.Key()))
+	}
+	if err := it.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func equalKeys(got []string, want ...string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMVCCDBIteration(t *testing.T) {
+	storage, _ := cdb.NewMemoryStorage()
+	for _, k := range []string{"a", "b", "c", "d"} {
+		storage.Put([]byte(k), []byte("v"+k))
+	}
+	db, _ := NewMVCCDB(storage, false)
+
+	if keys := iterateKeys(t, db.NewIterator()); !equalKeys(keys, "a", "b", "c", "d") {
+		t.Fatalf("iterate storage: %q", keys)
+	}
+
+	db.Begin()
+	db.Put([]byte("bb"), []byte("vbb"))
+	db.Delete([]byte("c"))
+	db.Put([]byte("e"), []byte("ve"))
+	if keys := iterateKeys(t, db.NewIterator()); !equalKeys(keys, "a", "b", "bb", "d", "e") {
+		t.Fatalf("iterate transaction: %q", keys)
+	}
+	if keys := iterateKeys(t, db.NewIteratorWithRange([]byte("b"), []byte("e"))); !equalKeys(keys, "b", "bb", "d") {
+		t.Fatalf("iterate transaction range: %q", keys)
+	}
+
+	pdb, err := db.Prepare("tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pdb.DeleteRange([]byte("b"), []byte("d")); err != nil {
+		t.Fatal(err)
+	}
+	if keys := iterateKeys(t, pdb.NewIterator()); !equalKeys(keys, "a", "d", "e") {
+		t.Fatalf("iterate prepared: %q", keys)
+	}
+	// the prepared changes are not visible to the parent until merged.
+	if keys := iterateKeys(t, db.NewIterator()); !equalKeys(keys, "a", "b", "bb", "d", "e") {
+		t.Fatalf("iterate transaction: %q", keys)
+	}
+	if _, err := pdb.CheckAndUpdate(); err != nil {
+		t.Fatal(err)
+	}
+	pdb.Close()
+
+	if err := db.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if keys := iterateKeys(t, storage.NewIterator()); !equalKeys(keys, "a", "d", "e") {
+		t.Fatalf("iterate committed storage: %q", keys)
+	}
+}
+
+func mustGetValue(t *testing.T, db *MVCCDB, key string, want string) {
+	value, err := db.Get([]byte(key))
+	if want == "" {
+		if err != cdb.ErrKeyNotFound {
+			t.Fatalf("get %q: %q %v, want not found", key, value, err)
+		}
+		return
+	}
+	if err != nil || string(value) != want {
+		t.Fatalf("get %q: %q %v, want %q", key, value, err, want)
+	}
+}
+
+func TestMVCCDBSavepoint(t *testing.T) {
+	storage, _ := cdb.NewMemoryStorage()
+	storage.Put([]byte("a"), []byte("va"))
+	db, _ := NewMVCCDB(storage, false)
+
+	if _, err := db.Savepoint(); err != ErrTransactionNotStarted {
+		t.Fatalf("savepoint out of transaction: %v", err)
+	}
+
+	db.Begin()
+	pdb, err := db.Prepare("tx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the fee of the outer transaction.
+	pdb.Put([]byte("fee"), []byte("1"))
+
+	outer, _ := pdb.Savepoint()
+	pdb.Put([]byte("a"), []byte("outer"))
+	pdb.Put([]byte("b"), []byte("vb"))
+
+	inner, _ := pdb.Savepoint()
+	pdb.Put([]byte("a"), []byte("inner"))
+	pdb.Delete([]byte("b"))
+	pdb.Put([]byte("c"), []byte("vc"))
+	mustGetValue(t, pdb, "a", "inner")
+
+	if err := pdb.RollBackToSavepoint(inner); err != nil {
+		t.Fatal(err)
+	}
+	mustGetValue(t, pdb, "a", "outer")
+	mustGetValue(t, pdb, "b", "vb")
+	mustGetValue(t, pdb, "c", "")
+	if err := pdb.RollBackToSavepoint(inner); err != ErrInvalidSavepoint {
+		t.Fatalf("roll back to a released savepoint: %v", err)
+	}
+
+	// releasing a nested savepoint keeps its changes in the enclosing one.
+	inner, _ = pdb.Savepoint()
+	pdb.Put([]byte("d"), []byte("vd"))
+	if err := pdb.ReleaseSavepoint(inner); err != nil {
+		t.Fatal(err)
+	}
+	mustGetValue(t, pdb, "d", "vd")
+	if err := pdb.RollBackToSavepoint(outer); err != nil {
+		t.Fatal(err)
+	}
+	mustGetValue(t, pdb, "a", "va")
+	mustGetValue(t, pdb, "b", "")
+	mustGetValue(t, pdb, "d", "")
+	mustGetValue(t, pdb, "fee", "1")
+
+	if _, err := pdb.CheckAndUpdate(); err != nil {
+		t.Fatal(err)
+	}
+	pdb.Close()
+
+	// the changes merged from a prepared db are rolled back too.
+	sp, _ := db.Savepoint()
+	pdb, _ = db.Prepare("tx2")
+	pdb.Put([]byte("fee"), []byte("2"))
+	if _, err := pdb.CheckAndUpdate(); err != nil {
+		t.Fatal(err)
+	}
+	pdb.Close()
+	mustGetValue(t, db, "fee", "2")
+	if err := db.RollBackToSavepoint(sp); err != nil {
+		t.Fatal(err)
+	}
+	mustGetValue(t, db, "fee", "1")
+
+	if err := db.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if keys := iterateKeys(t, storage.NewIteratorWithRange([]byte("a"), []byte("e"))); !equalKeys(keys, "a") {
+		t.Fatalf("iterate committed storage: %q", keys)
+	}
+	if value, _ := storage.Get([]byte("fee")); string(value) != "1" {
+		t.Fatalf("committed fee: %q", value)
+	}
+}
+
+func TestMVCCDBSavepointConflict(t *testing.T) {
+	storage, _ := cdb.NewMemoryStorage()
+	storage.Put([]byte("a"), []byte("va"))
+	db, _ := NewMVCCDB(storage, false)
+	db.Begin()
+
+	pdb1, _ := db.Prepare("tx1")
+	pdb2, _ := db.Prepare("tx2")
+
+	// tx1 reads a in a sub-call rolled back, the read is still a dependency.
+	sp, _ := pdb1.Savepoint()
+	mustGetValue(t, pdb1, "a", "va")
+	pdb1.Put([]byte("b"), []byte("vb"))
+	pdb1.RollBackToSavepoint(sp)
+
+	pdb2.Put([]byte("a"), []byte("changed"))
+	if _, err := pdb2.CheckAndUpdate(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pdb1.CheckAndUpdate(); err != ErrStagingTableKeyConfliction {
+		t.Fatalf("check and update: %v, want %v", err, ErrStagingTableKeyConfliction)
+	}
+}
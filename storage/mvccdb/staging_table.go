@@ -33,10 +33,15 @@ import (
 var (
 	ErrStagingTableKeyConfliction = errors.New("staging table key confliction")
 	ErrParentStagingTableIsNil    = errors.New("parent Staging Table is nil")
+	ErrInvalidSavepoint           = errors.New("invalid savepoint")
 )
 
 type stagingValuesMap map[string]*VersionizedValueItem
 
+// savepointJournal the values of the keys changed since a savepoint as they were
+// at the savepoint, nil for the keys absent from the table then.
+type savepointJournal map[string]*VersionizedValueItem
+
 // VersionizedValueItem a struct for key/value pair, with version, dirty, deleted flags.
 type VersionizedValueItem struct {
 	tid           interface{}
@@ -62,6 +67,7 @@ type StagingTable struct {
 	preparedStagingTables           map[interface{}]*StagingTable
 	isTrieSameKeyCompatibility      bool // The `isTrieSameKeyCompatibility` is used to prevent conflict in continuous changes with same key/value.
 	disableStrictGlobalVersionCheck bool // default `true`
+	savepoints                      []savepointJournal
 }
 
 // NewStagingTable return new instance of StagingTable.
@@ -165,6 +171,10 @@ func (tbl *StagingTable) GetByKey(key []byte, loadFromStorage bool) (*Versionize
 
 // Put put the key/val pair. If key does not exist, copy and incr version from `parentStagingTable` to record previous version.
 func (tbl *StagingTable) Put(key []byte, val []byte) (*VersionizedValueItem, error) {
+	tbl.mutex.Lock()
+	tbl.journal(byteutils.Hex(key))
+	tbl.mutex.Unlock()
+
 	value, err := tbl.GetByKey(key, false)
 	if err != nil {
 		return nil, err
@@ -185,6 +195,10 @@ func (tbl *StagingTable) Put(key []byte, val []byte) (*VersionizedValueItem, err
 
 // Del del the tid/key pair. If tid+key does not exist, copy and incr version from `finalVersionizedValues` to record previous version.
 func (tbl *StagingTable) Del(key []byte) (*VersionizedValueItem, error) {
+	tbl.mutex.Lock()
+	tbl.journal(byteutils.Hex(key))
+	tbl.mutex.Unlock()
+
 	value, err := tbl.GetByKey(key, false)
 	if err != nil {
 		return nil, err
@@ -214,6 +228,85 @@ func (tbl *StagingTable) Purge() {
 
 	// purge all content.
 	tbl.versionizedValues = make(stagingValuesMap)
+	tbl.savepoints = nil
+}
+
+// Savepoint mark the current values of the table, the changes made after it
+// can be discarded by RollBackTo. Savepoints nest, the ids grow from 0.
+func (tbl *StagingTable) Savepoint() int {
+	tbl.mutex.Lock()
+	defer tbl.mutex.Unlock()
+
+	tbl.savepoints = append(tbl.savepoints, make(savepointJournal))
+	return len(tbl.savepoints) - 1
+}
+
+// RollBackTo discard the changes made after the savepoint, including the ones
+// merged from prepared tables, and release it with the savepoints nested in it.
+// The values read after the savepoint are kept, so the conflict detection still
+// covers them.
+func (tbl *StagingTable) RollBackTo(savepoint int) error {
+	tbl.mutex.Lock()
+	defer tbl.mutex.Unlock()
+
+	if savepoint < 0 || savepoint >= len(tbl.savepoints) {
+		return ErrInvalidSavepoint
+	}
+
+	for i := len(tbl.savepoints) - 1; i >= savepoint; i-- {
+		for keyStr, value := range tbl.savepoints[i] {
+			if value == nil {
+				delete(tbl.versionizedValues, keyStr)
+			} else {
+				tbl.versionizedValues[keyStr] = value
+			}
+		}
+	}
+	tbl.savepoints = tbl.savepoints[:savepoint]
+	return nil
+}
+
+// Release release the savepoint with the savepoints nested in it, keeping the
+// changes made after it.
+func (tbl *StagingTable) Release(savepoint int) error {
+	tbl.mutex.Lock()
+	defer tbl.mutex.Unlock()
+
+	if savepoint < 0 || savepoint >= len(tbl.savepoints) {
+		return ErrInvalidSavepoint
+	}
+
+	// the enclosing savepoint keeps the values of the keys first changed after it.
+	if savepoint > 0 {
+		outer := tbl.savepoints[savepoint-1]
+		for i := savepoint; i < len(tbl.savepoints); i++ {
+			for keyStr, value := range tbl.savepoints[i] {
+				if _, ok := outer[keyStr]; !ok {
+					outer[keyStr] = value
+				}
+			}
+		}
+	}
+	tbl.savepoints = tbl.savepoints[:savepoint]
+	return nil
+}
+
+// journal record the value of the key before its first change since the
+// latest savepoint, the caller holds the mutex.
+func (tbl *StagingTable) journal(keyStr string) {
+	if len(tbl.savepoints) == 0 {
+		return
+	}
+	latest := tbl.savepoints[len(tbl.savepoints)-1]
+	if _, ok := latest[keyStr]; ok {
+		return
+	}
+	if value := tbl.versionizedValues[keyStr]; value != nil {
+		saved := *value
+		latest[keyStr] = &saved
+	} else {
+		latest[keyStr] = nil
+	}
 }
 
 // MergeToParent merge key/value pair of tid to `finalVersionizedValues` which the version of value are the same.
@@ -294,6 +387,7 @@ func (tbl *StagingTable) MergeToParent() ([]interface{}, error) {
 
 		// merge.
 		value := fromValueItem.CloneForMerge(tbl.parentStagingTable.globalVersion)
+		tbl.parentStagingTable.journal(keyStr)
 		targetValues[keyStr] = value
 	}
 
@@ -323,6 +417,25 @@ func (tbl *StagingTable) Detach() error {
 	return nil
 }
 
+// stagedValues return the values of the keys in [start, limit) staged in the table
+// or its parents, the values of the table override the ones of its parents.
+func (tbl *StagingTable) stagedValues(start []byte, limit []byte) stagingValuesMap {
+	values := make(stagingValuesMap)
+	if tbl.parentStagingTable != nil {
+		values = tbl.parentStagingTable.stagedValues(start, limit)
+	}
+
+	tbl.mutex.Lock()
+	defer tbl.mutex.Unlock()
+
+	for keyStr, value := range tbl.versionizedValues {
+		if cdb.KeyInRange(value.key, start, limit) {
+			values[keyStr] = value
+		}
+	}
+	return values
+}
+
 func (tbl *StagingTable) getVersionizedValues() stagingValuesMap {
 	return tbl.versionizedValues
 }
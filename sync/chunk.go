@@ -76,13 +76,21 @@ func verifyChunkHeaders(chunkHeaders *syncpb.ChunkHeaders) (bool, error) {
 		}
 
 		for _, blockHash := range chunkHeader.Headers {
-			_, _ = blocksTrie.Put(blockHash, blockHash)
+			_ = blocksTrie.Put(blockHash, blockHash)
 		}
 
-		_, _ = chunksTrie.Put(blocksTrie.RootHash(), blocksTrie.RootHash())
+		blocksRoot, err := blocksTrie.RootHash()
+		if err != nil {
+			return false, err
+		}
+		_ = chunksTrie.Put(blocksRoot, blocksRoot)
 	}
 
-	return bytes.Compare(chunksTrie.RootHash(), chunkHeaders.Root) == 0, nil
+	chunksRoot, err := chunksTrie.RootHash()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Compare(chunksRoot, chunkHeaders.Root) == 0, nil
 }
 
 func verifyChunkData(chunkHeader *syncpb.ChunkHeader, chunkData *syncpb.ChunkData) (bool, error) {
@@ -139,13 +147,17 @@ func verifyChunkData(chunkHeader *syncpb.ChunkHeader, chunkData *syncpb.ChunkDat
 			}).Debug("Wrong block hash.")
 			return false, ErrWrongBlockHashInChunk
 		}
-		_, _ = blocksTrie.Put(block.Header.Hash, block.Header.Hash)
+		_ = blocksTrie.Put(block.Header.Hash, block.Header.Hash)
 	}
 
-	if bytes.Compare(blocksTrie.RootHash(), chunkHeader.Root) != 0 {
+	blocksRoot, err := blocksTrie.RootHash()
+	if err != nil {
+		return false, err
+	}
+	if bytes.Compare(blocksRoot, chunkHeader.Root) != 0 {
 		logging.VLog().WithFields(logrus.Fields{
 			"size":                len(chunkData.Blocks),
-			"localChunkRootHash":  byteutils.Hex(blocksTrie.RootHash()),
+			"localChunkRootHash":  byteutils.Hex(blocksRoot),
 			"chunkHeader":         chunkHeader,
 			"chunkHeaderRootHash": byteutils.Hex(chunkHeader.Root),
 		}).Debug("Wrong chunk header root hash.")
@@ -192,13 +204,17 @@ func (c *Chunk) generateChunkData(chunkHeader *syncpb.ChunkHeader) (*syncpb.Chun
 			return nil, err
 		}
 		blocks = append(blocks, pbBlock.(*corepb.Block))
-		_, _ = blocksTrie.Put(block.Hash(), block.Hash())
+		_ = blocksTrie.Put(block.Hash(), block.Hash())
 	}
 
-	if bytes.Compare(blocksTrie.RootHash(), chunkHeader.Root) != 0 {
+	blocksRoot, err := blocksTrie.RootHash()
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Compare(blocksRoot, chunkHeader.Root) != 0 {
 		logging.VLog().WithFields(logrus.Fields{
 			"size":                len(blocks),
-			"localChunkRootHash":  byteutils.Hex(blocksTrie.RootHash()),
+			"localChunkRootHash":  byteutils.Hex(blocksRoot),
 			"chunkHeader":         chunkHeader,
 			"chunkHeaderRootHash": byteutils.Hex(chunkHeader.Root),
 		}).Debug("Wrong chunk header root hash.")
@@ -209,7 +225,7 @@ func (c *Chunk) generateChunkData(chunkHeader *syncpb.ChunkHeader) (*syncpb.Chun
 		"size": len(blocks),
 	}).Debug("Succeed to generate chunk.")
 
-	return &syncpb.ChunkData{Blocks: blocks, Root: blocksTrie.RootHash()}, nil
+	return &syncpb.ChunkData{Blocks: blocks, Root: blocksRoot}, nil
 }
 
 func (c *Chunk) processChunkData(chunk *syncpb.ChunkData) error {
@@ -208,11 +208,15 @@ func (c *Chunk) generateChunkHeaders(syncpointHash byteutils.Hash) (*syncpb.Chun
 				return nil, ErrCannotFindBlockByHeight
 			}
 			headers = append(headers, block.Hash())
-			_, _ = blocksTrie.Put(block.Hash(), block.Hash())
+			_ = blocksTrie.Put(block.Hash(), block.Hash())
 			curHeight++
 		}
-		chunkHeaders = append(chunkHeaders, &syncpb.ChunkHeader{Headers: headers, Root: blocksTrie.RootHash()})
-		_, _ = chunksTrie.Put(blocksTrie.RootHash(), blocksTrie.RootHash())
+		blocksRoot, err := blocksTrie.RootHash()
+		if err != nil {
+			return nil, err
+		}
+		chunkHeaders = append(chunkHeaders, &syncpb.ChunkHeader{Headers: headers, Root: blocksRoot})
+		_ = chunksTrie.Put(blocksRoot, blocksRoot)
 
 		curChunk++
 	}
@@ -224,7 +228,11 @@ func (c *Chunk) generateChunkHeaders(syncpointHash byteutils.Hash) (*syncpb.Chun
 		"limit":     MaxChunkPerSyncRequest,
 		"synced":    len(chunkHeaders),
 	}).Debug("Succeed to generate chunks meta info.")
-	return &syncpb.ChunkHeaders{ChunkHeaders: chunkHeaders, Root: chunksTrie.RootHash()}, nil
+	chunksRoot, err := chunksTrie.RootHash()
+	if err != nil {
+		return nil, err
+	}
+	return &syncpb.ChunkHeaders{ChunkHeaders: chunkHeaders, Root: chunksRoot}, nil
 }
 
 func (ss *Service) chunkHeadersResponse(peerID string, chunks *syncpb.ChunkHeaders) {
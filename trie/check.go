@@ -0,0 +1,109 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package trie
+
+import (
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"bytes"
+	"github.com/pkg/errors"
+)
+
+// Errors
+var (
+	ErrCorruptedNode = errors.New("trie node is corrupted")
+)
+
+// CheckIntegrity walk all the nodes of the trie, verifying that every node
+// is present in storage and matches its hash. The subtries rooted at the
+// hashes in seen are skipped, the nodes are added to seen only if the whole
+// trie is verified, so the same map can be shared to check tries sharing nodes. leafFn is called with
+// the value of every leaf visited. It returns the number of nodes checked.
+func (t *Trie) CheckIntegrity(seen map[string]bool, leafFn func(value []byte) error) (int, error) {
+	return t.walkNodes(seen, nil, leafFn)
+}
+
+// CopyNodes copy all the nodes of the trie into dst, verifying them like
+// CheckIntegrity. The subtries rooted at the hashes in seen are assumed to
+// be in dst already. It returns the number of nodes copied.
+func (t *Trie) CopyNodes(dst cdb.Storage, seen map[string]bool, leafFn func(value []byte) error) (int, error) {
+	return t.walkNodes(seen, func(n *node) error {
+		return dst.Put(n.Hash, n.Bytes)
+	}, leafFn)
+}
+
+func (t *Trie) walkNodes(seen map[string]bool, visitFn func(n *node) error, leafFn func(value []byte) error) (int, error) {
+	if t.Empty() {
+		return 0, nil
+	}
+	if err := t.hash(); err != nil {
+		return 0, err
+	}
+
+	visited := make(map[string]bool)
+	stack := [][]byte{t.rootHash}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[string(hash)] || visited[string(hash)] {
+			continue
+		}
+
+		n, err := t.fetchNode(hash)
+		if err != nil {
+			return len(visited), errors.Wrapf(err, "failed to fetch trie node %x", hash)
+		}
+		if !bytes.Equal(n.Hash, hash) {
+			return len(visited), errors.Wrapf(ErrCorruptedNode, "trie node %x", hash)
+		}
+		flag, err := n.Type()
+		if err != nil {
+			return len(visited), errors.Wrapf(ErrCorruptedNode, "trie node %x: %v", hash, err)
+		}
+		if visitFn != nil {
+			if err := visitFn(n); err != nil {
+				return len(visited), err
+			}
+		}
+		switch flag {
+		case branch:
+			for _, child := range n.Val {
+				if len(child) > 0 {
+					stack = append(stack, child)
+				}
+			}
+		case ext:
+			stack = append(stack, n.Val[2])
+		case leaf:
+			if leafFn != nil {
+				if err := leafFn(n.Val[2]); err != nil {
+					return len(visited), err
+				}
+			}
+		default:
+			return len(visited), errors.Wrapf(ErrCorruptedNode, "trie node %x: unknown node type", hash)
+		}
+		visited[string(hash)] = true
+	}
+
+	if seen != nil {
+		for hash := range visited {
+			seen[hash] = true
+		}
+	}
+	return len(visited), nil
+}
@@ -0,0 +1,245 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package trie
+
+import (
+	"bytes"
+
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+)
+
+// diffNode a node visited by a diff, with its position: its route, or the
+// route of its key for a leaf.
+type diffNode struct {
+	hash  []byte
+	node  *node
+	flag  ty
+	route []byte
+}
+
+// nodeWalker walk the nodes of a trie in pre-order, which is the order of
+// their positions, possibly skipping the children of the current node.
+type nodeWalker struct {
+	trie    *Trie
+	pending []*diffNode
+	current *diffNode
+}
+
+func newNodeWalker(t *Trie) *nodeWalker {
+	w := &nodeWalker{trie: t}
+	if !t.Empty() {
+		w.pending = append(w.pending, &diffNode{hash: t.rootHash})
+	}
+	return w
+}
+
+// next move to the next node, the children of the current node are skipped
+// unless descend.
+func (w *nodeWalker) next(descend bool) error {
+	if cur := w.current; descend && cur != nil {
+		switch cur.flag {
+		case branch:
+			for i := 15; i >= 0; i-- {
+				if len(cur.node.Val[i]) > 0 {
+					route := append(append([]byte{}, cur.route...), byte(i))
+					w.pending = append(w.pending, &diffNode{hash: cur.node.Val[i], route: route})
+				}
+			}
+		case ext:
+			route := append(append([]byte{}, cur.route...), cur.node.Val[1]...)
+			w.pending = append(w.pending, &diffNode{hash: cur.node.Val[2], route: route})
+		}
+	}
+
+	w.current = nil
+	if len(w.pending) == 0 {
+		return nil
+	}
+	dn := w.pending[len(w.pending)-1]
+	w.pending = w.pending[:len(w.pending)-1]
+
+	n, err := w.trie.fetchNode(dn.hash)
+	if err != nil {
+		return err
+	}
+	if dn.flag, err = n.Type(); err != nil {
+		return err
+	}
+	dn.node = n
+	if dn.flag == leaf {
+		dn.route = append(dn.route, n.Val[1]...)
+	}
+	w.current = dn
+	return nil
+}
+
+// DiffIterator iterate in order the keys added, modified or removed between
+// two roots of a trie
+type DiffIterator struct {
+	old     *nodeWalker
+	new     *nodeWalker
+	started bool
+
+	action Action
+	key    []byte
+	oldVal []byte
+	newVal []byte
+}
+
+// Diff return an iterator over the changes from oldRoot to newRoot in storage,
+// the identical subtries are skipped by hash, nil roots are empty tries
+func Diff(storage cdb.Storage, oldRoot []byte, newRoot []byte) (*DiffIterator, error) {
+	oldTrie, err := NewTrie(oldRoot, storage, false)
+	if err != nil {
+		return nil, err
+	}
+	newTrie, err := NewTrie(newRoot, storage, false)
+	if err != nil {
+		return nil, err
+	}
+	return DiffTries(oldTrie, newTrie)
+}
+
+// DiffTries return an iterator over the changes from oldTrie to newTrie,
+// which may have dirty nodes
+func DiffTries(oldTrie *Trie, newTrie *Trie) (*DiffIterator, error) {
+	// hash the dirty nodes, the subtries are compared by hash.
+	if err := oldTrie.hash(); err != nil {
+		return nil, err
+	}
+	if err := newTrie.hash(); err != nil {
+		return nil, err
+	}
+	return &DiffIterator{
+		old: newNodeWalker(oldTrie),
+		new: newNodeWalker(newTrie),
+	}, nil
+}
+
+// Next return if there is next change
+func (it *DiffIterator) Next() (bool, error) {
+	if !it.started {
+		it.started = true
+		if err := it.old.next(false); err != nil {
+			return false, err
+		}
+		if err := it.new.next(false); err != nil {
+			return false, err
+		}
+	}
+
+	for {
+		a, b := it.old.current, it.new.current
+		if a == nil && b == nil {
+			return false, nil
+		}
+
+		cmp := 0
+		switch {
+		case a == nil:
+			cmp = 1
+		case b == nil:
+			cmp = -1
+		default:
+			cmp = bytes.Compare(a.route, b.route)
+		}
+
+		switch {
+		case cmp < 0:
+			// only in the old trie.
+			if err := it.old.next(true); err != nil {
+				return false, err
+			}
+			if a.flag == leaf {
+				it.set(Delete, a.route, a.node.Val[2], nil)
+				return true, nil
+			}
+		case cmp > 0:
+			// only in the new trie.
+			if err := it.new.next(true); err != nil {
+				return false, err
+			}
+			if b.flag == leaf {
+				it.set(Insert, b.route, nil, b.node.Val[2])
+				return true, nil
+			}
+		case bytes.Equal(a.hash, b.hash):
+			// the same subtrie at the same position.
+			if err := it.old.next(false); err != nil {
+				return false, err
+			}
+			if err := it.new.next(false); err != nil {
+				return false, err
+			}
+		case a.flag == leaf && b.flag == leaf:
+			if err := it.old.next(false); err != nil {
+				return false, err
+			}
+			if err := it.new.next(false); err != nil {
+				return false, err
+			}
+			// the same key may be held by leaves at different depths.
+			if !bytes.Equal(a.node.Val[2], b.node.Val[2]) {
+				it.set(Update, a.route, a.node.Val[2], b.node.Val[2])
+				return true, nil
+			}
+		case a.flag == leaf:
+			if err := it.new.next(true); err != nil {
+				return false, err
+			}
+		case b.flag == leaf:
+			if err := it.old.next(true); err != nil {
+				return false, err
+			}
+		default:
+			if err := it.old.next(true); err != nil {
+				return false, err
+			}
+			if err := it.new.next(true); err != nil {
+				return false, err
+			}
+		}
+	}
+}
+
+func (it *DiffIterator) set(action Action, route []byte, oldVal []byte, newVal []byte) {
+	it.action = action
+	it.key = routeToKey(route)
+	it.oldVal = oldVal
+	it.newVal = newVal
+}
+
+// Action return the change of current key, Insert, Update or Delete
+func (it *DiffIterator) Action() Action {
+	return it.action
+}
+
+// Key return current changed key
+func (it *DiffIterator) Key() []byte {
+	return it.key
+}
+
+// OldValue return the value of current key at the old root, nil if inserted
+func (it *DiffIterator) OldValue() []byte {
+	return it.oldVal
+}
+
+// NewValue return the value of current key at the new root, nil if deleted
+func (it *DiffIterator) NewValue() []byte {
+	return it.newVal
+}
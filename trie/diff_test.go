@@ -0,0 +1,188 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+)
+
+type diffChange struct {
+	action Action
+	oldVal string
+	newVal string
+}
+
+// collectDiff return the changes from oldRoot to newRoot by key, and check
+// that they come in key order.
+func collectDiff(t *testing.T, storage cdb.Storage, oldRoot []byte, newRoot []byte) map[string]diffChange {
+	it, err := Diff(storage, oldRoot, newRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := make(map[string]diffChange)
+	var last []byte
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			break
+		}
+		if last != nil && bytes.Compare(last, it.Key()) >= 0 {
+			t.Fatalf("diff key %x after %x", it.Key(), last)
+		}
+		last = it.Key()
+		changes[string(it.Key())] = diffChange{it.Action(), string(it.OldValue()), string(it.NewValue())}
+	}
+	return changes
+}
+
+func checkDiff(t *testing.T, got map[string]diffChange, want map[string]diffChange) {
+	if len(got) != len(want) {
+		t.Fatalf("%d changes, want %d", len(got), len(want))
+	}
+	for key, w := range want {
+		if g, ok := got[key]; !ok || g != w {
+			t.Fatalf("change of %x is %v, want %v", key, g, w)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tr, storage := newTestTrie(t)
+	for i := 0; i < 64; i++ {
+		if err := tr.Put(testKey(i), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tr.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	oldRoot := rootHash(t, tr)
+
+	want := make(map[string]diffChange)
+	for i := 0; i < 64; i += 4 {
+		if err := tr.Del(testKey(i)); err != nil {
+			t.Fatal(err)
+		}
+		want[string(testKey(i))] = diffChange{Delete, fmt.Sprintf("value%d", i), ""}
+	}
+	for i := 1; i < 64; i += 4 {
+		if err := tr.Put(testKey(i), []byte(fmt.Sprintf("updated%d", i))); err != nil {
+			t.Fatal(err)
+		}
+		want[string(testKey(i))] = diffChange{Update, fmt.Sprintf("value%d", i), fmt.Sprintf("updated%d", i)}
+	}
+	// putting the same value again is no change.
+	if err := tr.Put(testKey(2), []byte("value2")); err != nil {
+		t.Fatal(err)
+	}
+	for i := 64; i < 80; i++ {
+		if err := tr.Put(testKey(i), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+		want[string(testKey(i))] = diffChange{Insert, "", fmt.Sprintf("value%d", i)}
+	}
+	if err := tr.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	newRoot := rootHash(t, tr)
+
+	checkDiff(t, collectDiff(t, storage, oldRoot, newRoot), want)
+
+	// the reverse diff swaps inserts and deletes.
+	reverse := make(map[string]diffChange)
+	for key, c := range want {
+		switch c.action {
+		case Insert:
+			reverse[key] = diffChange{Delete, c.newVal, ""}
+		case Delete:
+			reverse[key] = diffChange{Insert, "", c.oldVal}
+		default:
+			reverse[key] = diffChange{Update, c.newVal, c.oldVal}
+		}
+	}
+	checkDiff(t, collectDiff(t, storage, newRoot, oldRoot), reverse)
+}
+
+func TestDiffEmptyAndIdenticalRoots(t *testing.T) {
+	tr, storage := newTestTrie(t)
+	all := make(map[string]diffChange)
+	for i := 0; i < 16; i++ {
+		if err := tr.Put(testKey(i), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+		all[string(testKey(i))] = diffChange{Insert, "", fmt.Sprintf("value%d", i)}
+	}
+	if err := tr.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	root := rootHash(t, tr)
+
+	// from the empty trie every key is inserted, and back every key is deleted.
+	checkDiff(t, collectDiff(t, storage, nil, root), all)
+	deleted := make(map[string]diffChange)
+	for key, c := range all {
+		deleted[key] = diffChange{Delete, c.newVal, ""}
+	}
+	checkDiff(t, collectDiff(t, storage, root, nil), deleted)
+
+	checkDiff(t, collectDiff(t, storage, root, root), nil)
+	checkDiff(t, collectDiff(t, storage, nil, nil), nil)
+}
+
+func TestDiffTriesDirty(t *testing.T) {
+	oldTrie, storage := newTestTrie(t)
+	for i := 0; i < 16; i++ {
+		if err := oldTrie.Put(testKey(i), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := oldTrie.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the new trie isn't committed, its dirty nodes are diffed.
+	newTrie, err := NewTrie(rootHash(t, oldTrie), storage, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := newTrie.Put(testKey(3), []byte("updated3")); err != nil {
+		t.Fatal(err)
+	}
+	it, err := DiffTries(oldTrie, newTrie)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := it.Next()
+	if err != nil || !ok {
+		t.Fatalf("no change of dirty trie: %v", err)
+	}
+	if it.Action() != Update || !bytes.Equal(it.Key(), testKey(3)) || string(it.NewValue()) != "updated3" {
+		t.Fatalf("change %v of %x to %q, want update of %x", it.Action(), it.Key(), it.NewValue(), testKey(3))
+	}
+	if ok, err := it.Next(); err != nil || ok {
+		t.Fatalf("more than one change of dirty trie: %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+package trie
+
+import (
+	"encoding/binary"
+
+	"gamc.pro/gamcio/go-gamc/crypto/hash"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// Errors
+var (
+	ErrMissingDirtyNode = errors.New("dirty trie node is missing")
+)
+
+const (
+	// the references of the dirty nodes are 9 bytes long, a hash is 32 bytes long.
+	refFlag = 0xff
+	refLen  = 9
+)
+
+// dirtyNodes the nodes of a trie changed since its last commit, kept in memory.
+// A new node is referenced by a temporary reference until the trie is hashed,
+// then by its hash until the trie is committed. The nodes are never changed
+// once added, so the tries cloned from a trie may share them.
+type dirtyNodes struct {
+	seq    uint64
+	nodes  map[string]*node // the unhashed nodes by reference.
+	hashed map[string]*node // the hashed nodes by hash, with their bytes.
+}
+
+func newDirtyNodes() *dirtyNodes {
+	return &dirtyNodes{
+		nodes:  make(map[string]*node),
+		hashed: make(map[string]*node),
+	}
+}
+
+func (d *dirtyNodes) clone() *dirtyNodes {
+	c := &dirtyNodes{
+		seq:    d.seq,
+		nodes:  make(map[string]*node, len(d.nodes)),
+		hashed: make(map[string]*node, len(d.hashed)),
+	}
+	for ref, n := range d.nodes {
+		c.nodes[ref] = n
+	}
+	for h, n := range d.hashed {
+		c.hashed[h] = n
+	}
+	return c
+}
+
+func (d *dirtyNodes) empty() bool {
+	return len(d.nodes) == 0 && len(d.hashed) == 0
+}
+
+// add a new node, setting its temporary reference as its hash.
+func (d *dirtyNodes) add(n *node) {
+	d.seq++
+	ref := make([]byte, refLen)
+	ref[0] = refFlag
+	binary.BigEndian.PutUint64(ref[1:], d.seq)
+	n.Hash = ref
+	n.Bytes = nil
+	d.nodes[string(ref)] = n
+}
+
+// get return a copy of the dirty node, nil if it's not dirty.
+func (d *dirtyNodes) get(hash []byte) (*node, error) {
+	n := d.hashed[string(hash)]
+	if n == nil && isRef(hash) {
+		if n = d.nodes[string(hash)]; n == nil {
+			return nil, ErrMissingDirtyNode
+		}
+	}
+	if n == nil {
+		return nil, nil
+	}
+	val := make([][]byte, len(n.Val))
+	for i, v := range n.Val {
+		if v != nil {
+			val[i] = append([]byte{}, v...)
+		}
+	}
+	return &node{Hash: n.Hash, Bytes: n.Bytes, Val: val}, nil
+}
+
+// hash compute the hashes of the unhashed nodes reachable from root, which
+// turn hashed, and return the hash of root. The other unhashed nodes are dropped.
+func (d *dirtyNodes) hash(root []byte) ([]byte, error) {
+	if !isRef(root) {
+		return root, nil
+	}
+	resolved := make(map[string][]byte)
+	h, err := d.hashNode(root, resolved)
+	if err != nil {
+		return nil, err
+	}
+	d.nodes = make(map[string]*node)
+	return h, nil
+}
+
+func (d *dirtyNodes) hashNode(ref []byte, resolved map[string][]byte) ([]byte, error) {
+	if h, ok := resolved[string(ref)]; ok {
+		return h, nil
+	}
+	n := d.nodes[string(ref)]
+	if n == nil {
+		return nil, ErrMissingDirtyNode
+	}
+	flag, err := n.Type()
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve the references to the children, the value of a leaf is kept as is.
+	val := make([][]byte, len(n.Val))
+	copy(val, n.Val)
+	switch flag {
+	case branch:
+		for i, child := range val {
+			if isRef(child) {
+				if val[i], err = d.hashNode(child, resolved); err != nil {
+					return nil, err
+				}
+			}
+		}
+	case ext:
+		if isRef(val[2]) {
+			if val[2], err = d.hashNode(val[2], resolved); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	hashed := &node{Val: val}
+	if err := hashed.encode(); err != nil {
+		return nil, err
+	}
+	d.hashed[string(hashed.Hash)] = hashed
+	resolved[string(ref)] = hashed.Hash
+	return hashed.Hash, nil
+}
+
+// collect return the hashed nodes reachable from root, the subtries already
+// written are skipped. The buffer is emptied.
+func (d *dirtyNodes) collect(root []byte) []*node {
+	var nodes []*node
+	stack := [][]byte{root}
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n := d.hashed[string(h)]
+		if n == nil {
+			continue
+		}
+		delete(d.hashed, string(h))
+		nodes = append(nodes, n)
+
+		flag, _ := n.Type()
+		switch flag {
+		case branch:
+			for _, child := range n.Val {
+				if len(child) > 0 {
+					stack = append(stack, child)
+				}
+			}
+		case ext:
+			stack = append(stack, n.Val[2])
+		}
+	}
+	d.nodes = make(map[string]*node)
+	d.hashed = make(map[string]*node)
+	return nodes
+}
+
+// encode serialize the node, and set its hash.
+func (n *node) encode() error {
+	pb, err := n.ToProto()
+	if err != nil {
+		return err
+	}
+	if n.Bytes, err = proto.Marshal(pb); err != nil {
+		return err
+	}
+	n.Hash = hash.Sha3256(n.Bytes)
+	return nil
+}
+
+func isRef(hash []byte) bool {
+	return len(hash) == refLen && hash[0] == refFlag
+}
@@ -18,8 +18,11 @@
 package trie
 
 import (
-	"gamc.pro/gamcio/go-gamc/crypto/hash"
+	"bytes"
 	"errors"
+
+	"gamc.pro/gamcio/go-gamc/crypto/hash"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
 )
 
 // errors constants
@@ -27,58 +30,97 @@ var (
 	ErrNotIterable = errors.New("leaf node is not iterable")
 )
 
-// IteratorState represents the intermediate statue in iterator
+// IteratorState represents a subtrie left to iterate, its root and route
 type IteratorState struct {
-	node  *node
-	pos   int
+	hash  []byte
 	route []byte
 }
 
-// Iterator to traverse leaf node in a trie
+// Iterator to traverse leaf node in a trie, in order of keys or in reverse
+// order, within a range of keys
 type Iterator struct {
-	stack []*IteratorState
-	value []byte
-	key   []byte
-	root  *Trie
-}
+	stack   []*IteratorState
+	value   []byte
+	key     []byte
+	root    *Trie
+	reverse bool
+	err     error
 
-func validElementsInBranchNode(offset int, node *node) []int {
-	var valid []int
-	ty, _ := node.Type()
-	if ty != branch {
-		return valid
-	}
-	for i := offset; i < 16; i++ {
-		if node.Val[i] != nil && len(node.Val[i]) > 0 {
-			valid = append(valid, i)
-		}
-	}
-	return valid
+	// the routes of the range of the iterator, and of the range left after a
+	// seek. A nil limit means no upper bound.
+	lower []byte
+	upper []byte
+	start []byte
+	limit []byte
 }
 
 // Iterator return an iterator
 func (t *Trie) Iterator(prefix []byte) (*Iterator, error) {
-	rootHash, curRoute, err := t.getSubTrieWithMaxCommonPrefix(prefix)
-	if err != nil {
-		return nil, err
+	if t.Empty() {
+		return nil, ErrNotFound
 	}
-	node, err := t.fetchNode(rootHash)
-	if err != nil {
+	// fail if no key has the prefix.
+	if _, _, err := t.getSubTrieWithMaxCommonPrefix(prefix); err != nil {
 		return nil, err
 	}
+	start, limit := cdb.BytesPrefix(prefix)
+	it := t.newIterator(start, limit, false)
+	if it.err != nil {
+		return nil, it.err
+	}
+	return it, nil
+}
+
+// IteratorWithRange return an iterator of the keys in [start, limit) in
+// order, a nil limit means no upper bound
+func (t *Trie) IteratorWithRange(start []byte, limit []byte) *Iterator {
+	return t.newIterator(start, limit, false)
+}
+
+// ReverseIteratorWithRange return an iterator of the keys in [start, limit)
+// in reverse order, a nil limit means no upper bound
+func (t *Trie) ReverseIteratorWithRange(start []byte, limit []byte) *Iterator {
+	return t.newIterator(start, limit, true)
+}
+
+func (t *Trie) newIterator(start []byte, limit []byte, reverse bool) *Iterator {
+	it := &Iterator{
+		root:    t,
+		reverse: reverse,
+		lower:   keyToRoute(start),
+	}
+	if limit != nil {
+		it.upper = keyToRoute(limit)
+	}
+	// hash the dirty nodes, so the iterator refers to the nodes by hash.
+	it.err = t.hash()
+	it.reset(it.lower, it.upper)
+	return it
+}
 
-	pos := -1
-	valid := validElementsInBranchNode(0, node)
-	if len(valid) > 0 {
-		pos = valid[0]
+func (it *Iterator) reset(start []byte, limit []byte) {
+	it.start, it.limit = start, limit
+	it.stack = nil
+	it.key, it.value = nil, nil
+	if !it.root.Empty() {
+		it.push(it.root.rootHash, []byte{})
 	}
+}
 
-	return &Iterator{
-		root:  t,
-		stack: []*IteratorState{&IteratorState{node, pos, curRoute}},
-		value: nil,
-		key:   nil,
-	}, nil
+// Seek move the iterator to key, the next call of Next returns the first key
+// at or after key in the order of iteration, within the range of the iterator
+func (it *Iterator) Seek(key []byte) {
+	start, limit := it.lower, it.upper
+	if it.reverse {
+		// the keys at or before key are below key + 0x00.
+		route := keyToRoute(append(append([]byte{}, key...), 0))
+		if limit == nil || bytes.Compare(route, limit) < 0 {
+			limit = route
+		}
+	} else if route := keyToRoute(key); bytes.Compare(route, start) > 0 {
+		start = route
+	}
+	it.reset(start, limit)
 }
 
 func (t *Trie) getSubTrieWithMaxCommonPrefix(prefix []byte) ([]byte, []byte, error) {
@@ -128,8 +170,8 @@ func (t *Trie) getSubTrieWithMaxCommonPrefix(prefix []byte) ([]byte, []byte, err
 	return curRootHash, route, nil
 }
 
-func (it *Iterator) push(node *node, pos int, route []byte) {
-	it.stack = append(it.stack, &IteratorState{node, pos, route})
+func (it *Iterator) push(hash []byte, route []byte) {
+	it.stack = append(it.stack, &IteratorState{hash, route})
 }
 
 func (it *Iterator) pop() (*IteratorState, error) {
@@ -144,47 +186,56 @@ func (it *Iterator) pop() (*IteratorState, error) {
 
 // Next return if there is next leaf node
 func (it *Iterator) Next() (bool, error) {
-	state, err := it.pop()
-	if err != nil {
-		return false, nil
+	if it.err != nil {
+		return false, it.err
 	}
-	node := state.node
-	pos := state.pos
-	route := state.route
-	ty, err := node.Type()
-	for {
+	for len(it.stack) > 0 {
+		state, err := it.pop()
+		if err != nil {
+			return false, err
+		}
+		node, err := it.root.fetchNode(state.hash)
+		if err != nil {
+			return false, err
+		}
+		ty, err := node.Type()
+		if err != nil {
+			return false, err
+		}
 		switch ty {
 		case branch:
-			valid := validElementsInBranchNode(pos, node)
-			if len(valid) == 0 {
-				return false, errors.New("empty branch node")
+			// push the subtries in the range, the one to visit first last.
+			for i := 0; i < 16; i++ {
+				idx := 15 - i
+				if it.reverse {
+					idx = i
+				}
+				if len(node.Val[idx]) == 0 {
+					continue
+				}
+				route := append(append([]byte{}, state.route...), byte(idx))
+				if mayHaveRouteInRange(route, it.start, it.limit) {
+					it.push(node.Val[idx], route)
+				}
 			}
-			if len(valid) > 1 {
-				//curRoute := append(route, []byte{byte(valid[1])}...)
-				it.push(node, valid[1], route)
-			}
-			route = append(route, []byte{byte(valid[0])}...)
-			node, err = it.root.fetchNode(node.Val[valid[0]])
-			if err != nil {
-				return false, err
-			}
-			ty, err = node.Type()
 		case ext:
-			route = append(route, node.Val[1]...)
-			node, err = it.root.fetchNode(node.Val[2])
-			if err != nil {
-				return false, err
+			route := append(append([]byte{}, state.route...), node.Val[1]...)
+			if mayHaveRouteInRange(route, it.start, it.limit) {
+				it.push(node.Val[2], route)
 			}
-			ty, err = node.Type()
 		case leaf:
+			route := append(append([]byte{}, state.route...), node.Val[1]...)
+			if bytes.Compare(route, it.start) < 0 || (it.limit != nil && bytes.Compare(route, it.limit) >= 0) {
+				continue
+			}
 			it.value = node.Val[2]
-			it.key = append(route, node.Val[1]...)
+			it.key = route
 			return true, nil
 		default:
-			return false, err
+			return false, errors.New("unknown node type")
 		}
-		pos = 0
 	}
+	return false, nil
 }
 
 // Key return current leaf node's key
@@ -0,0 +1,179 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// iterate return the keys left in the iterator, checking their values.
+func iterate(t *testing.T, tr *Trie, it *Iterator) [][]byte {
+	var keys [][]byte
+	for {
+		ok, err := it.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			return keys
+		}
+		val, err := tr.Get(it.Key())
+		if err != nil || !bytes.Equal(val, it.Value()) {
+			t.Fatalf("iterator value of %x is %q, want %q", it.Key(), it.Value(), val)
+		}
+		keys = append(keys, it.Key())
+	}
+}
+
+func checkKeys(t *testing.T, got [][]byte, want [][]byte) {
+	if len(got) != len(want) {
+		t.Fatalf("%d keys, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if !bytes.Equal(got[i], want[i]) {
+			t.Fatalf("key %d is %x, want %x", i, got[i], want[i])
+		}
+	}
+}
+
+func reversed(keys [][]byte) [][]byte {
+	r := make([][]byte, 0, len(keys))
+	for i := len(keys) - 1; i >= 0; i-- {
+		r = append(r, keys[i])
+	}
+	return r
+}
+
+func TestIteratorPrefix(t *testing.T) {
+	// newProofTrie return the keys in order.
+	tr, keys := newProofTrie(t, 64)
+
+	it, err := tr.Iterator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkKeys(t, iterate(t, tr, it), keys)
+
+	prefix := keys[10][:1]
+	var want [][]byte
+	for _, key := range keys {
+		if bytes.HasPrefix(key, prefix) {
+			want = append(want, key)
+		}
+	}
+	it, err = tr.Iterator(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkKeys(t, iterate(t, tr, it), want)
+
+	// a whole key is a prefix of itself only.
+	it, err = tr.Iterator(keys[10])
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkKeys(t, iterate(t, tr, it), keys[10:11])
+
+	if _, err := tr.Iterator(absentKey(keys)); err != ErrNotFound {
+		t.Fatalf("iterator of a missing prefix: %v, want %v", err, ErrNotFound)
+	}
+	empty, _ := newTestTrie(t)
+	if _, err := empty.Iterator(nil); err != ErrNotFound {
+		t.Fatalf("iterator of empty trie: %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestIteratorRange(t *testing.T) {
+	tr, keys := newProofTrie(t, 64)
+
+	checkKeys(t, iterate(t, tr, tr.IteratorWithRange(nil, nil)), keys)
+	checkKeys(t, iterate(t, tr, tr.IteratorWithRange(keys[5], keys[20])), keys[5:20])
+	checkKeys(t, iterate(t, tr, tr.IteratorWithRange(keys[60], nil)), keys[60:])
+	checkKeys(t, iterate(t, tr, tr.ReverseIteratorWithRange(nil, nil)), reversed(keys))
+	checkKeys(t, iterate(t, tr, tr.ReverseIteratorWithRange(keys[5], keys[20])), reversed(keys[5:20]))
+	checkKeys(t, iterate(t, tr, tr.ReverseIteratorWithRange(nil, keys[3])), reversed(keys[:3]))
+
+	// empty ranges.
+	checkKeys(t, iterate(t, tr, tr.IteratorWithRange(keys[5], keys[5])), nil)
+	checkKeys(t, iterate(t, tr, tr.IteratorWithRange(keys[20], keys[5])), nil)
+	checkKeys(t, iterate(t, tr, tr.ReverseIteratorWithRange(keys[5], keys[5])), nil)
+	checkKeys(t, iterate(t, tr, tr.IteratorWithRange(nil, keys[0])), nil)
+
+	empty, _ := newTestTrie(t)
+	checkKeys(t, iterate(t, empty, empty.IteratorWithRange(nil, nil)), nil)
+	checkKeys(t, iterate(t, empty, empty.ReverseIteratorWithRange(nil, nil)), nil)
+}
+
+func TestIteratorSeek(t *testing.T) {
+	tr, keys := newProofTrie(t, 64)
+	last := keys[len(keys)-1]
+
+	it := tr.IteratorWithRange(nil, nil)
+	it.Seek(keys[30])
+	checkKeys(t, iterate(t, tr, it), keys[30:])
+
+	// a key not in the trie seeks to the next one.
+	missing := append(append([]byte{}, keys[30]...), 0)
+	it.Seek(missing)
+	checkKeys(t, iterate(t, tr, it), keys[31:])
+
+	// past the last key there is nothing left.
+	it.Seek(append(append([]byte{}, last...), 0))
+	checkKeys(t, iterate(t, tr, it), nil)
+
+	// a seek doesn't leave the range of the iterator.
+	it = tr.IteratorWithRange(keys[10], keys[20])
+	it.Seek(keys[0])
+	checkKeys(t, iterate(t, tr, it), keys[10:20])
+	it.Seek(keys[15])
+	checkKeys(t, iterate(t, tr, it), keys[15:20])
+	it.Seek(keys[40])
+	checkKeys(t, iterate(t, tr, it), nil)
+
+	// in reverse order the seek moves to the key at or before it.
+	it = tr.ReverseIteratorWithRange(nil, nil)
+	it.Seek(keys[30])
+	checkKeys(t, iterate(t, tr, it), reversed(keys[:31]))
+	it.Seek(missing)
+	checkKeys(t, iterate(t, tr, it), reversed(keys[:31]))
+	it.Seek(append(append([]byte{}, last...), 0))
+	checkKeys(t, iterate(t, tr, it), reversed(keys))
+
+	it = tr.ReverseIteratorWithRange(keys[10], keys[20])
+	it.Seek(keys[40])
+	checkKeys(t, iterate(t, tr, it), reversed(keys[10:20]))
+	it.Seek(keys[0])
+	checkKeys(t, iterate(t, tr, it), nil)
+}
+
+func TestIteratorDirtyTrie(t *testing.T) {
+	tr, keys := newProofTrie(t, 16)
+
+	// the iterator sees the puts not committed yet.
+	key := testKey(100)
+	if err := tr.Put(key, []byte(fmt.Sprintf("value%d", 100))); err != nil {
+		t.Fatal(err)
+	}
+	count := len(iterate(t, tr, tr.IteratorWithRange(nil, nil)))
+	if count != len(keys)+1 {
+		t.Fatalf("%d keys in dirty trie, want %d", count, len(keys)+1)
+	}
+}
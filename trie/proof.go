@@ -23,6 +23,13 @@ import (
 	"errors"
 )
 
+// Errors
+var (
+	ErrInvalidProof    = errors.New("invalid merkle proof")
+	ErrIncompleteProof = errors.New("merkle proof is incomplete")
+	ErrKeyExists       = errors.New("key exists in trie")
+)
+
 // MerkleProof is a path from root to the proved node
 // every element in path is the value of a node
 type MerkleProof [][][]byte
@@ -31,91 +38,284 @@ type MerkleProof [][][]byte
 // if exists, MerkleProof is a complete path from root to the node
 // otherwise, MerkleProof is nil
 func (t *Trie) Prove(key []byte) (MerkleProof, error) {
+	proof, found, err := t.provePath(key)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrNotFound
+	}
+	return proof, nil
+}
+
+// ProveAbsence prove the key doesn't exist in trie, MerkleProof is the path
+// from root to the node where the key diverges, empty if the trie is empty
+func (t *Trie) ProveAbsence(key []byte) (MerkleProof, error) {
+	proof, found, err := t.provePath(key)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return nil, ErrKeyExists
+	}
+	return proof, nil
+}
+
+// provePath return the path from root towards the key, and if the key exists
+func (t *Trie) provePath(key []byte) (MerkleProof, bool, error) {
 	curRoute := keyToRoute(key)
-	curRootHash := t.rootHash
+	curRootHash, err := t.RootHash()
+	if err != nil {
+		return nil, false, err
+	}
 	var proof MerkleProof
-	for len(curRoute) > 0 {
+	for len(curRootHash) > 0 {
 		// fetch sub-trie root node
 		rootNode, err := t.fetchNode(curRootHash)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		flag, err := rootNode.Type()
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
+		proof = append(proof, rootNode.Val)
 		switch flag {
 		case branch:
-			proof = append(proof, rootNode.Val)
+			if len(curRoute) == 0 {
+				return nil, false, errors.New("wrong key, too short")
+			}
 			curRootHash = rootNode.Val[curRoute[0]]
 			curRoute = curRoute[1:]
 		case ext:
 			path := rootNode.Val[1]
-			next := rootNode.Val[2]
-			matchLen := prefixLen(path, curRoute)
-			if matchLen != len(path) {
-				return nil, ErrNotFound
+			if !bytes.HasPrefix(curRoute, path) {
+				return proof, false, nil
 			}
-			proof = append(proof, rootNode.Val)
-			curRootHash = next
-			curRoute = curRoute[matchLen:]
+			curRootHash = rootNode.Val[2]
+			curRoute = curRoute[len(path):]
 		case leaf:
-			path := rootNode.Val[1]
-			matchLen := prefixLen(path, curRoute)
-			if matchLen != len(path) {
-				return nil, ErrNotFound
-			}
-			proof = append(proof, rootNode.Val)
-			return proof, nil
+			return proof, bytes.Equal(rootNode.Val[1], curRoute), nil
 		default:
-			return nil, ErrNotFound
+			return nil, false, errors.New("unknown node type")
 		}
 	}
-	return nil, ErrNotFound
+	return proof, false, nil
 }
 
 // Verify whether the merkle proof from root to the associated node is right
 func (t *Trie) Verify(rootHash []byte, key []byte, proof MerkleProof) error {
+	_, found, err := verifyPath(rootHash, key, proof)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// VerifyAbsence whether the merkle proof proves the key doesn't exist at root
+func (t *Trie) VerifyAbsence(rootHash []byte, key []byte, proof MerkleProof) error {
+	_, found, err := verifyPath(rootHash, key, proof)
+	if err != nil {
+		return err
+	}
+	if found {
+		return ErrKeyExists
+	}
+	return nil
+}
+
+// verifyPath walk the proof from root towards the key, checking the hash of
+// every node. It returns the value of the key if it exists.
+func verifyPath(rootHash []byte, key []byte, proof MerkleProof) ([]byte, bool, error) {
 	curRoute := keyToRoute(key)
-	length := len(proof)
 	wantHash := rootHash
-	for i := 0; i < length; i++ {
-		val := proof[i]
-		n, err := t.createNode(val)
-		if err != nil {
-			return err
+	if len(wantHash) == 0 {
+		// an empty trie has no key.
+		if len(proof) > 0 {
+			return nil, false, ErrInvalidProof
 		}
-		proofHash := n.Hash
-		if !bytes.Equal(wantHash, proofHash) {
-			return errors.New("wrong hash")
+		return nil, false, nil
+	}
+	for i, val := range proof {
+		last := i == len(proof)-1
+		n := &node{Val: val}
+		if err := n.encode(); err != nil {
+			return nil, false, err
+		}
+		if !bytes.Equal(wantHash, n.Hash) {
+			return nil, false, ErrInvalidProof
 		}
-		switch len(val) {
-		case 16: // Branch Node
+		flag, err := n.Type()
+		if err != nil {
+			return nil, false, ErrInvalidProof
+		}
+		switch flag {
+		case branch:
+			if len(curRoute) == 0 {
+				return nil, false, ErrInvalidProof
+			}
 			wantHash = val[curRoute[0]]
 			curRoute = curRoute[1:]
-			break
-		case 3: // Extension Node or Leaf Node
-			if val[0] == nil || len(val) == 0 {
-				return errors.New("unknown node type")
+			if len(wantHash) == 0 {
+				if !last {
+					return nil, false, ErrInvalidProof
+				}
+				return nil, false, nil
 			}
-			if val[0][0] == byte(ext) {
-				extLen := len(val[1])
-				if !bytes.Equal(val[1], curRoute[:extLen]) {
-					return errors.New("wrong hash")
+		case ext:
+			if !bytes.HasPrefix(curRoute, val[1]) {
+				if !last {
+					return nil, false, ErrInvalidProof
 				}
-				wantHash = val[2]
-				curRoute = curRoute[extLen:]
-				break
-			} else if val[0][0] == byte(leaf) {
-				if !bytes.Equal(val[1], curRoute) {
-					return errors.New("wrong hash")
+				return nil, false, nil
+			}
+			wantHash = val[2]
+			curRoute = curRoute[len(val[1]):]
+		case leaf:
+			if !last {
+				return nil, false, ErrInvalidProof
+			}
+			if bytes.Equal(val[1], curRoute) {
+				return val[2], true, nil
+			}
+			return nil, false, nil
+		default:
+			return nil, false, ErrInvalidProof
+		}
+	}
+	return nil, false, ErrIncompleteProof
+}
+
+// ProveRange prove all the keys in [start, limit) of the trie, a nil limit
+// means no upper bound. The proof holds every node of the subtries that may
+// have a key in the range, so the keys and their values can be verified and
+// got from it by VerifyRange, and no key in the range can be missed.
+func (t *Trie) ProveRange(start []byte, limit []byte) (MerkleProof, error) {
+	rootHash, err := t.RootHash()
+	if err != nil {
+		return nil, err
+	}
+	if len(rootHash) == 0 {
+		return nil, nil
+	}
+	var proof MerkleProof
+	err = walkRange(t.fetchNode, rootHash, start, limit, func(n *node, key []byte) error {
+		proof = append(proof, n.Val)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyRange verify the range proof of [start, limit) at root, and return
+// in order all the keys in the range and their values.
+func (t *Trie) VerifyRange(rootHash []byte, start []byte, limit []byte, proof MerkleProof) ([][]byte, [][]byte, error) {
+	if len(rootHash) == 0 {
+		if len(proof) > 0 {
+			return nil, nil, ErrInvalidProof
+		}
+		return nil, nil, nil
+	}
+
+	nodes := make(map[string]*node, len(proof))
+	for _, val := range proof {
+		n := &node{Val: val}
+		if err := n.encode(); err != nil {
+			return nil, nil, err
+		}
+		nodes[string(n.Hash)] = n
+	}
+	fetch := func(hash []byte) (*node, error) {
+		n := nodes[string(hash)]
+		if n == nil {
+			return nil, ErrIncompleteProof
+		}
+		return n, nil
+	}
+
+	var keys, values [][]byte
+	err := walkRange(fetch, rootHash, start, limit, func(n *node, key []byte) error {
+		if key != nil {
+			keys = append(keys, key)
+			values = append(values, n.Val[2])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
+
+// walkRange visit in order the nodes of the trie at rootHash which may lead to
+// a key in [start, limit), with the key of the leaves in the range.
+func walkRange(fetch func(hash []byte) (*node, error), rootHash []byte, start []byte, limit []byte, visitFn func(n *node, key []byte) error) error {
+	startRoute := keyToRoute(start)
+	var limitRoute []byte
+	if limit != nil {
+		limitRoute = keyToRoute(limit)
+	}
+
+	var walk func(hash []byte, route []byte) error
+	walk = func(hash []byte, route []byte) error {
+		n, err := fetch(hash)
+		if err != nil {
+			return err
+		}
+		flag, err := n.Type()
+		if err != nil {
+			return ErrInvalidProof
+		}
+		switch flag {
+		case branch:
+			if err := visitFn(n, nil); err != nil {
+				return err
+			}
+			for i, child := range n.Val {
+				childRoute := append(append([]byte{}, route...), byte(i))
+				if len(child) > 0 && mayHaveRouteInRange(childRoute, startRoute, limitRoute) {
+					if err := walk(child, childRoute); err != nil {
+						return err
+					}
 				}
-				return nil
 			}
-			return errors.New("unknown node type")
+		case ext:
+			if err := visitFn(n, nil); err != nil {
+				return err
+			}
+			childRoute := append(append([]byte{}, route...), n.Val[1]...)
+			if mayHaveRouteInRange(childRoute, startRoute, limitRoute) {
+				return walk(n.Val[2], childRoute)
+			}
+		case leaf:
+			var key []byte
+			leafRoute := append(append([]byte{}, route...), n.Val[1]...)
+			if bytes.Compare(leafRoute, startRoute) >= 0 && (limitRoute == nil || bytes.Compare(leafRoute, limitRoute) < 0) {
+				key = routeToKey(leafRoute)
+			}
+			return visitFn(n, key)
 		default:
-			return errors.New("wrong node value, expect [16][]byte or [3][]byte, get [" + string(len(proofHash)) + "][]byte")
+			return ErrInvalidProof
 		}
+		return nil
 	}
-	return nil
+	return walk(rootHash, nil)
+}
+
+// mayHaveRouteInRange report whether a route starting with prefix may be in
+// [start, limit), a nil limit means no upper bound
+func mayHaveRouteInRange(prefix []byte, start []byte, limit []byte) bool {
+	n := len(prefix)
+	if len(start) < n {
+		n = len(start)
+	}
+	if bytes.Compare(prefix[:n], start[:n]) < 0 {
+		return false
+	}
+	return limit == nil || bytes.Compare(prefix, limit) < 0
 }
@@ -0,0 +1,210 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// newProofTrie return a committed trie of n keys and its keys in order.
+func newProofTrie(t *testing.T, n int) (*Trie, [][]byte) {
+	tr, _ := newTestTrie(t)
+	var keys [][]byte
+	for i := 0; i < n; i++ {
+		if err := tr.Put(testKey(i), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, testKey(i))
+	}
+	if err := tr.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	return tr, keys
+}
+
+// copyProof return a deep copy of the proof, to be changed by the test.
+func copyProof(proof MerkleProof) MerkleProof {
+	c := make(MerkleProof, len(proof))
+	for i, val := range proof {
+		c[i] = make([][]byte, len(val))
+		for j, v := range val {
+			c[i][j] = append([]byte{}, v...)
+		}
+	}
+	return c
+}
+
+// changeNode flip a byte of the last non-empty value of the node.
+func changeNode(val [][]byte) {
+	for i := len(val) - 1; i >= 0; i-- {
+		if len(val[i]) > 0 {
+			val[i][0] ^= 0xff
+			return
+		}
+	}
+}
+
+// absentKey return a key not in the trie, next to an existing one.
+func absentKey(keys [][]byte) []byte {
+	key := append([]byte{}, keys[0]...)
+	key[len(key)-1] ^= 0x01
+	return key
+}
+
+func TestProveAbsence(t *testing.T) {
+	tr, keys := newProofTrie(t, 32)
+	root := rootHash(t, tr)
+
+	if _, err := tr.ProveAbsence(keys[0]); err != ErrKeyExists {
+		t.Fatalf("prove absence of an existing key: %v, want %v", err, ErrKeyExists)
+	}
+
+	key := absentKey(keys)
+	proof, err := tr.ProveAbsence(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.VerifyAbsence(root, key, proof); err != nil {
+		t.Fatalf("verify absence: %v", err)
+	}
+	if err := tr.VerifyAbsence(root, keys[0], proof); err == nil {
+		t.Fatal("absence proof verified for an existing key")
+	}
+
+	// the same proof fails against the root of another trie.
+	other, _ := newProofTrie(t, 33)
+	if err := tr.VerifyAbsence(rootHash(t, other), key, proof); err != ErrInvalidProof {
+		t.Fatalf("verify absence against another root: %v, want %v", err, ErrInvalidProof)
+	}
+
+	// an empty trie proves the absence of any key with an empty proof.
+	empty, _ := newTestTrie(t)
+	proof, err = empty.ProveAbsence(key)
+	if err != nil || len(proof) != 0 {
+		t.Fatalf("prove absence in empty trie: %v, %d nodes", err, len(proof))
+	}
+	if err := empty.VerifyAbsence(nil, key, proof); err != nil {
+		t.Fatalf("verify absence in empty trie: %v", err)
+	}
+}
+
+func TestProofTampered(t *testing.T) {
+	tr, keys := newProofTrie(t, 32)
+	root := rootHash(t, tr)
+
+	proof, err := tr.Prove(keys[5])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Verify(root, keys[5], proof); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	// without its last node the proof doesn't reach the key.
+	if err := tr.Verify(root, keys[5], proof[:len(proof)-1]); err != ErrIncompleteProof {
+		t.Fatalf("verify proof with a node removed: %v, want %v", err, ErrIncompleteProof)
+	}
+
+	for i := range proof {
+		changed := copyProof(proof)
+		changeNode(changed[i])
+		if err := tr.Verify(root, keys[5], changed); err != ErrInvalidProof {
+			t.Fatalf("verify proof with node %d changed: %v, want %v", i, err, ErrInvalidProof)
+		}
+	}
+}
+
+func TestProveRange(t *testing.T) {
+	tr, keys := newProofTrie(t, 32)
+	root := rootHash(t, tr)
+
+	check := func(start, limit []byte, want [][]byte) {
+		proof, err := tr.ProveRange(start, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, values, err := tr.VerifyRange(root, start, limit, proof)
+		if err != nil {
+			t.Fatalf("verify range [%x, %x): %v", start, limit, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("range [%x, %x): %d keys, want %d", start, limit, len(got), len(want))
+		}
+		for i := range want {
+			if !bytes.Equal(got[i], want[i]) {
+				t.Fatalf("range [%x, %x): key %d is %x, want %x", start, limit, i, got[i], want[i])
+			}
+			if val, _ := tr.Get(want[i]); !bytes.Equal(values[i], val) {
+				t.Fatalf("range [%x, %x): value of %x is %q, want %q", start, limit, got[i], values[i], val)
+			}
+		}
+	}
+
+	// the whole key space, and its edges.
+	check(nil, nil, keys)
+	check(nil, keys[1], keys[:1])
+	check(keys[len(keys)-1], nil, keys[len(keys)-1:])
+	check(keys[3], keys[10], keys[3:10])
+
+	// empty ranges.
+	check(keys[3], keys[3], nil)
+	check(absentKey(keys), absentKey(keys), nil)
+	check(nil, keys[0], nil)
+
+	// an empty trie has an empty proof of any range.
+	empty, _ := newTestTrie(t)
+	if proof, err := empty.ProveRange(nil, nil); err != nil || len(proof) != 0 {
+		t.Fatalf("prove range in empty trie: %v, %d nodes", err, len(proof))
+	}
+}
+
+func TestRangeProofTampered(t *testing.T) {
+	tr, keys := newProofTrie(t, 32)
+	root := rootHash(t, tr)
+
+	proof, err := tr.ProveRange(keys[3], keys[10])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range proof {
+		removed := append(copyProof(proof[:i]), copyProof(proof[i+1:])...)
+		if _, _, err := tr.VerifyRange(root, keys[3], keys[10], removed); err != ErrIncompleteProof {
+			t.Fatalf("verify range proof with node %d removed: %v, want %v", i, err, ErrIncompleteProof)
+		}
+	}
+
+	// a changed node no longer has the hash its parent refers to, so the
+	// range proof misses that node.
+	for i := range proof {
+		changed := copyProof(proof)
+		changeNode(changed[i])
+		if _, _, err := tr.VerifyRange(root, keys[3], keys[10], changed); err != ErrIncompleteProof {
+			t.Fatalf("verify range proof with node %d changed: %v, want %v", i, err, ErrIncompleteProof)
+		}
+	}
+
+	// a range proof against another root doesn't verify.
+	if _, _, err := tr.VerifyRange(testKey(100), keys[3], keys[10], proof); err != ErrIncompleteProof {
+		t.Fatalf("verify range proof against another root: %v, want %v", err, ErrIncompleteProof)
+	}
+}
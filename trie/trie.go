@@ -18,6 +18,8 @@
 package trie
 
 import (
+	"strconv"
+
 	"gamc.pro/gamcio/go-gamc/crypto/hash"
 	"gamc.pro/gamcio/go-gamc/storage/cdb"
 	"gamc.pro/gamcio/go-gamc/trie/pb"
@@ -106,7 +108,7 @@ func (n *node) Type() (ty, error) {
 		}
 		return ty(n.Val[0][0]), nil
 	default:
-		return unknown, errors.New("wrong node value, expect [16][]byte or [3][]byte, get [" + string(len(n.Val)) + "][]byte")
+		return unknown, errors.New("wrong node value, expect [16][]byte or [3][]byte, get [" + strconv.Itoa(len(n.Val)) + "][]byte")
 	}
 }
 
@@ -114,11 +116,14 @@ func (n *node) Type() (ty, error) {
 // Branch Node: 16-elements array, value is [hash_0, hash_1, ..., hash_f, hash]
 // Extension Node: 3-elements array, value is [ext flag, prefix path, next hash]
 // Leaf Node: 3-elements array, value is [leaf flag, suffix path, value]
+// The changed nodes are kept in memory, hashed by RootHash and written into
+// the storage by Commit.
 type Trie struct {
 	rootHash      []byte
 	storage       cdb.Storage
 	changelog     []*Entry
 	needChangelog bool
+	dirty         *dirtyNodes
 }
 
 // CreateNode in trie
@@ -132,6 +137,9 @@ func (t *Trie) createNode(val [][]byte) (*node, error) {
 
 // FetchNode in trie
 func (t *Trie) fetchNode(hash []byte) (*node, error) {
+	if n, err := t.dirty.get(hash); n != nil || err != nil {
+		return n, err
+	}
 	ir, err := t.storage.Get(hash)
 
 	if err != nil {
@@ -149,19 +157,10 @@ func (t *Trie) fetchNode(hash []byte) (*node, error) {
 	return n, nil
 }
 
-// CommitNode node in trie into storage
+// CommitNode node in trie into the dirty nodes, its hash is a temporary reference until hashed
 func (t *Trie) commitNode(n *node) error {
-	pb, err := n.ToProto()
-	if err != nil {
-		return err
-	}
-	n.Bytes, err = proto.Marshal(pb)
-	if err != nil {
-		return err
-	}
-	n.Hash = hash.Sha3256(n.Bytes)
-
-	return t.storage.Put(n.Hash, n.Bytes)
+	t.dirty.add(n)
+	return nil
 }
 
 // NewTrie if rootHash is nil, create a new Trie, otherwise, build an existed trie
@@ -170,6 +169,7 @@ func NewTrie(rootHash []byte, storage cdb.Storage, needChangelog bool) (*Trie, e
 		rootHash:      rootHash,
 		storage:       storage,
 		needChangelog: needChangelog,
+		dirty:         newDirtyNodes(),
 	}
 	if t.rootHash == nil || len(t.rootHash) == 0 {
 		return t, nil
@@ -179,9 +179,41 @@ func NewTrie(rootHash []byte, storage cdb.Storage, needChangelog bool) (*Trie, e
 	return t, nil
 }
 
-// RootHash return the rootHash of trie
-func (t *Trie) RootHash() []byte {
-	return t.rootHash
+// RootHash return the rootHash of trie, hashing the dirty nodes
+func (t *Trie) RootHash() ([]byte, error) {
+	if err := t.hash(); err != nil {
+		return nil, err
+	}
+	return t.rootHash, nil
+}
+
+func (t *Trie) hash() error {
+	rootHash, err := t.dirty.hash(t.rootHash)
+	if err != nil {
+		return err
+	}
+	t.rootHash = rootHash
+	return nil
+}
+
+// Commit write the dirty nodes reachable from the root into the storage in one batch
+func (t *Trie) Commit() error {
+	if t.dirty.empty() {
+		return nil
+	}
+	if err := t.hash(); err != nil {
+		return err
+	}
+	batch := t.storage.NewWriteBatch()
+	for _, n := range t.dirty.collect(t.rootHash) {
+		if err := batch.Put(n.Hash, n.Bytes); err != nil {
+			return err
+		}
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	return batch.Write()
 }
 
 // Empty return if the trie is empty
@@ -239,10 +271,12 @@ func (t *Trie) get(rootHash []byte, route []byte) ([]byte, error) {
 }
 
 // Put the key-value pair in trie
-func (t *Trie) Put(key []byte, val []byte) ([]byte, error) {
+func (t *Trie) Put(key []byte, val []byte) error {
+	// the value is kept in memory until the trie is hashed.
+	val = append([]byte{}, val...)
 	newHash, err := t.update(t.rootHash, keyToRoute(key), val)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	t.rootHash = newHash
 
@@ -251,7 +285,7 @@ func (t *Trie) Put(key []byte, val []byte) ([]byte, error) {
 		t.changelog = append(t.changelog, entry)
 	}
 
-	return newHash, nil
+	return nil
 }
 
 func (t *Trie) update(root []byte, route []byte, val []byte) ([]byte, error) {
@@ -451,10 +485,10 @@ func (t *Trie) updateWhenMeetLeaf(rootNode *node, route []byte, val []byte) ([]b
 		leaf leaf			leaf leaf
 
 */
-func (t *Trie) Del(key []byte) ([]byte, error) {
+func (t *Trie) Del(key []byte) error {
 	newHash, err := t.del(t.rootHash, keyToRoute(key))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	t.rootHash = newHash
 
@@ -462,7 +496,7 @@ func (t *Trie) Del(key []byte) ([]byte, error) {
 		entry := &Entry{Delete, key, nil, nil}
 		t.changelog = append(t.changelog, entry)
 	}
-	return newHash, nil
+	return nil
 }
 
 func (t *Trie) del(root []byte, route []byte) ([]byte, error) {
@@ -612,32 +646,56 @@ func (t *Trie) deleteWhenMeetSingleBranch(rootNode *node) ([]byte, error) {
 	return nil, nil
 }
 
+// Revision of a trie, its root hash and the length of its changelog
+type Revision struct {
+	rootHash []byte
+	changes  int
+}
+
+// Revision return the current revision of the trie, hashing the dirty nodes
+func (t *Trie) Revision() (Revision, error) {
+	rootHash, err := t.RootHash()
+	if err != nil {
+		return Revision{}, err
+	}
+	return Revision{rootHash: rootHash, changes: len(t.changelog)}, nil
+}
+
+// RevertTo revert the trie to a previous revision, dropping the changelog after it.
+// The revisions taken before the last commit may only be reverted to if the
+// root was committed.
+func (t *Trie) RevertTo(rev Revision) {
+	t.rootHash = rev.rootHash
+	if rev.changes < len(t.changelog) {
+		t.changelog = t.changelog[:rev.changes]
+	}
+}
+
 // Clone the trie to create a new trie sharing the same storage
 func (t *Trie) Clone() (*Trie, error) {
-	return &Trie{rootHash: t.rootHash, storage: t.storage, needChangelog: t.needChangelog}, nil
+	return &Trie{rootHash: t.rootHash, storage: t.storage, needChangelog: t.needChangelog, dirty: t.dirty.clone()}, nil
 }
 
 // CopyTo copy the trie structure into the given storage
 func (t *Trie) CopyTo(storage cdb.Storage, needChangelog bool) (*Trie, error) {
-	return &Trie{rootHash: t.rootHash, storage: storage, needChangelog: needChangelog}, nil
+	return &Trie{rootHash: t.rootHash, storage: storage, needChangelog: needChangelog, dirty: t.dirty.clone()}, nil
 }
 
-// Replay return roothash not save key to storage
-func (t *Trie) Replay(ft *Trie) ([]byte, error) {
+// Replay the changelog of ft, not save key to storage
+func (t *Trie) Replay(ft *Trie) error {
 
 	needChangelog := t.needChangelog
 	t.needChangelog = false
 
 	var err error
-	var rootHash []byte
 
 	for _, entry := range ft.changelog {
 		switch entry.action {
 		case Delete:
-			rootHash, err = t.Del(entry.key)
+			err = t.Del(entry.key)
 			break
 		case Update, Insert:
-			rootHash, err = t.Put(entry.key, entry.update)
+			err = t.Put(entry.key, entry.update)
 			break
 		default:
 			err = nil
@@ -645,13 +703,13 @@ func (t *Trie) Replay(ft *Trie) ([]byte, error) {
 
 		if err != nil {
 			t.needChangelog = needChangelog
-			return nil, err
+			return err
 		}
 	}
 	ft.changelog = make([]*Entry, 0)
 
 	t.needChangelog = needChangelog
-	return rootHash, nil
+	return nil
 }
 
 // prefixLen returns the length of the common prefix between a and b.
@@ -0,0 +1,203 @@
+// Copyright (C) 2018 go-gamc authors
+//
+// This file is part of the go-gamc library.
+//
+// the go-gamc library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// the go-gamc library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with the go-gamc library.  If not, see <http://www.gnu.org/licenses/>.
+//
+
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"gamc.pro/gamcio/go-gamc/crypto/hash"
+	"gamc.pro/gamcio/go-gamc/storage/cdb"
+	"gamc.pro/gamcio/go-gamc/util/byteutils"
+)
+
+func testKey(i int) []byte {
+	return hash.Sha3256([]byte(fmt.Sprintf("key%d", i)))
+}
+
+func newTestTrie(t *testing.T) (*Trie, cdb.Storage) {
+	storage, _ := cdb.NewMemoryStorage()
+	tr, err := NewTrie(nil, storage, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr, storage
+}
+
+func rootHash(t *testing.T, tr *Trie) []byte {
+	root, err := tr.RootHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// storedKeys return the keys in the storage.
+func storedKeys(storage cdb.Storage) map[string]bool {
+	keys := make(map[string]bool)
+	it := storage.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		keys[string(it.Key())] = true
+	}
+	return keys
+}
+
+// reachableNodes return the hashes of the nodes reachable from root in storage.
+func reachableNodes(t *testing.T, storage cdb.Storage, root []byte) map[string]bool {
+	tr, err := NewTrie(root, storage, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := make(map[string]bool)
+	stack := [][]byte{root}
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		nodes[string(h)] = true
+		n, err := tr.fetchNode(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		flag, _ := n.Type()
+		switch flag {
+		case branch:
+			for _, child := range n.Val {
+				if len(child) > 0 {
+					stack = append(stack, child)
+				}
+			}
+		case ext:
+			stack = append(stack, n.Val[2])
+		}
+	}
+	return nodes
+}
+
+func TestTrieRootHashMatchesWriteThrough(t *testing.T) {
+	// the roots of the same sequence on the trie writing every node through.
+	want := []string{
+		"590460ca2260d4dbab6763d1662ddb92d9e4dcf570e45012e1ca7a86c20f2f1e",
+		"6244ebb4951fd463dce8262d85de1c221ce5a08b37ead35c4f67dad960add500",
+		"d53fbec480e8a3eeda33cfe72d721784e80a0b2aeec4b91b739b462b70d7ab03",
+	}
+
+	tr, _ := newTestTrie(t)
+	for i := 0; i < 64; i++ {
+		if err := tr.Put(testKey(i), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := byteutils.Hex(rootHash(t, tr)); got != want[0] {
+		t.Fatalf("root after put %s, want %s", got, want[0])
+	}
+	for i := 0; i < 16; i++ {
+		if err := tr.Put(testKey(i), []byte(fmt.Sprintf("updated%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := byteutils.Hex(rootHash(t, tr)); got != want[1] {
+		t.Fatalf("root after update %s, want %s", got, want[1])
+	}
+	for i := 0; i < 64; i += 3 {
+		if err := tr.Del(testKey(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := byteutils.Hex(rootHash(t, tr)); got != want[2] {
+		t.Fatalf("root after del %s, want %s", got, want[2])
+	}
+}
+
+func TestTrieCommitAndReopen(t *testing.T) {
+	tr, storage := newTestTrie(t)
+	for i := 0; i < 32; i++ {
+		if err := tr.Put(testKey(i), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(storedKeys(storage)) != 0 {
+		t.Fatal("dirty nodes written before commit")
+	}
+	if err := tr.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewTrie(rootHash(t, tr), storage, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 32; i++ {
+		val, err := reopened.Get(testKey(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("value%d", i); string(val) != want {
+			t.Fatalf("get key%d %q, want %q", i, val, want)
+		}
+	}
+
+	// the trie keeps working on the committed nodes.
+	if err := reopened.Put(testKey(32), []byte("value32")); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	again, err := NewTrie(rootHash(t, reopened), storage, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val, err := again.Get(testKey(32)); err != nil || !bytes.Equal(val, []byte("value32")) {
+		t.Fatalf("get key32 %q, %v", val, err)
+	}
+}
+
+func TestTrieCommitWritesReachableNodesOnly(t *testing.T) {
+	tr, storage := newTestTrie(t)
+	for i := 0; i < 64; i++ {
+		if err := tr.Put(testKey(i), []byte(fmt.Sprintf("value%d", i))); err != nil {
+			t.Fatal(err)
+		}
+		// hash in between, so some hashed nodes are replaced before commit.
+		if i%8 == 0 {
+			rootHash(t, tr)
+		}
+	}
+	for i := 0; i < 64; i += 2 {
+		if err := tr.Del(testKey(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tr.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := storedKeys(storage)
+	reachable := reachableNodes(t, storage, rootHash(t, tr))
+	if len(stored) != len(reachable) {
+		t.Fatalf("%d nodes written, %d reachable from root", len(stored), len(reachable))
+	}
+	for h := range stored {
+		if !reachable[h] {
+			t.Fatalf("unreachable node %s written", byteutils.Hex([]byte(h)))
+		}
+	}
+}
@@ -191,7 +191,7 @@ func InitConfig(fileName string) (*Config, error) {
 	}
 	in, err := ioutil.ReadFile(fileName)
 	if err != nil {
-		panic("Failed to read the config file:" + fileName + ". err:" + err.Error())
+		return nil, err
 	}
 	_config = new(Config)
 	_config.filePath = fileName